@@ -2,21 +2,30 @@ package api
 
 import (
 	"context"
+	"errors"
 	"net"
+	"net/http"
 
 	"github.com/google/uuid"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	log "github.com/sirupsen/logrus"
 	"github.com/zenazn/goji/graceful"
 	"google.golang.org/grpc"
-
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+
+	"github.com/mingkaic/accretion/config"
+	"github.com/mingkaic/accretion/data"
 	"github.com/mingkaic/accretion/proto/profile"
 	"github.com/mingkaic/accretion/service"
 )
 
 type (
 	AccretionAPI interface {
-		Run(string, string, chan error, []grpc.ServerOption, HTTPOpts)
+		Run(chan error, []grpc.ServerOption, HTTPOpts)
 	}
 
 	HTTPOpts struct {
@@ -25,55 +34,42 @@ type (
 	}
 
 	accretionAPI struct {
+		cfg    config.Config
+		store  data.Store
 		server profile.TenncorProfileServiceServer
 	}
 
 	tenncorProfileServiceServer struct {
 		profile.UnimplementedTenncorProfileServiceServer
+		svc service.GraphService
 	}
 )
 
-func NewTenncorProfileService() profile.TenncorProfileServiceServer {
-	return &tenncorProfileServiceServer{}
+func NewTenncorProfileService(svc service.GraphService) profile.TenncorProfileServiceServer {
+	return &tenncorProfileServiceServer{svc: svc}
 }
 
-func (tenncorProfileServiceServer) ListProfile(
+func (s *tenncorProfileServiceServer) ListProfile(
 	ctx context.Context, req *profile.ListProfileRequest) (
 	*profile.ListProfileResponse, error) {
 	log.Debug("listing profiles")
-	svc := service.NewGraphService()
-	profiles, err := svc.ListGraphProfiles()
-	if err != nil {
-		return nil, err
-	}
-	return &profile.ListProfileResponse{
-		Profiles: profiles,
-	}, nil
+	return s.svc.ListGraphProfiles(req)
 }
 
-func (tenncorProfileServiceServer) GetProfile(
+func (s *tenncorProfileServiceServer) GetProfile(
 	ctx context.Context, req *profile.GetProfileRequest) (
 	*profile.GetProfileResponse, error) {
 	profileId := req.GetProfileId()
-	log.Debug("getting profile %s", profileId)
-	svc := service.NewGraphService()
-	nodes, edges, err := svc.GetGraphProfile(profileId)
-	if err != nil {
-		return nil, err
-	}
-	return &profile.GetProfileResponse{
-		Nodes: nodes,
-		Edges: edges,
-	}, nil
+	log.Debugf("getting profile %s", profileId)
+	return s.svc.GetGraphProfile(req)
 }
 
-func (tenncorProfileServiceServer) CreateProfile(
+func (s *tenncorProfileServiceServer) CreateProfile(
 	ctx context.Context, req *profile.CreateProfileRequest) (
 	*profile.CreateProfileResponse, error) {
 	id := uuid.NewString()
 	log.Debugf("creating profile %s", id)
-	svc := service.NewGraphService()
-	if err := svc.CreateGraphProfile(id, req.Model, req.OperatorData); err != nil {
+	if err := s.svc.CreateGraphProfile(id, req); err != nil {
 		log.Debugf("failed profile %s creation: %v", id, err)
 		return nil, err
 	}
@@ -83,22 +79,94 @@ func (tenncorProfileServiceServer) CreateProfile(
 	}, nil
 }
 
-func NewAccretionAPI() AccretionAPI {
+func (s *tenncorProfileServiceServer) StreamCreateProfile(
+	stream profile.TenncorProfileService_StreamCreateProfileServer) error {
+	id := uuid.NewString()
+	log.Debugf("streaming profile %s", id)
+	if err := s.svc.StreamGraphProfile(id, stream); err != nil {
+		log.Debugf("failed profile %s creation: %v", id, err)
+		return err
+	}
+	log.Debugf("created profile %s", id)
+	return stream.SendAndClose(&profile.CreateProfileResponse{
+		ProfileId: id,
+	})
+}
+
+func (s *tenncorProfileServiceServer) DeleteProfile(
+	ctx context.Context, req *profile.DeleteProfileRequest) (
+	*profile.DeleteProfileResponse, error) {
+	profileId := req.GetProfileId()
+	log.Debugf("deleting profile %s", profileId)
+	return s.svc.DeleteGraphProfile(profileId)
+}
+
+func (s *tenncorProfileServiceServer) SetProfilePinned(
+	ctx context.Context, req *profile.SetProfilePinnedRequest) (
+	*profile.SetProfilePinnedResponse, error) {
+	profileId := req.GetProfileId()
+	log.Debugf("setting profile %s pinned to %t", profileId, req.GetPinned())
+	if err := s.svc.PinGraphProfile(profileId, req.GetPinned()); err != nil {
+		return nil, err
+	}
+	return &profile.SetProfilePinnedResponse{
+		ProfileId: profileId,
+		Pinned:    req.GetPinned(),
+	}, nil
+}
+
+func (s *tenncorProfileServiceServer) GetTensorData(
+	ctx context.Context, req *profile.GetTensorDataRequest) (
+	*profile.GetTensorDataResponse, error) {
+	log.Debugf("getting tensor %s of profile %s", req.GetNodeId(), req.GetProfileId())
+	return s.svc.GetTensorData(req)
+}
+
+func NewAccretionAPI(cfg config.Config, store data.Store) AccretionAPI {
 	out := &accretionAPI{
-		server: NewTenncorProfileService(),
+		cfg:    cfg,
+		store:  store,
+		server: NewTenncorProfileService(service.NewGraphService(store)),
 	}
 	return out
 }
 
-func (a *accretionAPI) Run(httpAddr, grpcAddr string, errs chan error, grpcOpts []grpc.ServerOption, httpOpts HTTPOpts) {
+// Run serves grpc and http on the configured addresses, receiving messages
+// up to the configured size unless grpcOpts say otherwise
+func (a *accretionAPI) Run(errs chan error, grpcOpts []grpc.ServerOption, httpOpts HTTPOpts) {
+	grpcOpts = append([]grpc.ServerOption{
+		grpc.MaxRecvMsgSize(a.cfg.MaxMessageBytes),
+		grpc.ChainUnaryInterceptor(unaryStoreStatus),
+		grpc.ChainStreamInterceptor(streamStoreStatus),
+	}, grpcOpts...)
 	go func() {
-		errs <- a.runGRPC(grpcAddr, grpcOpts)
+		errs <- a.runGRPC(a.cfg.GRPCAddr, grpcOpts)
 	}()
 	go func() {
-		errs <- a.runHTTP(httpAddr, grpcAddr, httpOpts.MuxOpts, httpOpts.DialOpts)
+		errs <- a.runHTTP(a.cfg.HTTPAddr, a.cfg.GRPCAddr, httpOpts.MuxOpts, httpOpts.DialOpts)
 	}()
 }
 
+// storeStatus reports requests failing while the store isn't ready
+// as unavailable, so clients know to retry them
+func storeStatus(err error) error {
+	if errors.Is(err, data.ErrNotReady) {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	return err
+}
+
+func unaryStoreStatus(ctx context.Context, req interface{},
+	info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	res, err := handler(ctx, req)
+	return res, storeStatus(err)
+}
+
+func streamStoreStatus(srv interface{}, stream grpc.ServerStream,
+	info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return storeStatus(handler(srv, stream))
+}
+
 func (a *accretionAPI) runGRPC(addr string, opts []grpc.ServerOption) error {
 	listener, err := net.Listen("tcp", addr)
 	if err != nil {
@@ -106,7 +174,19 @@ func (a *accretionAPI) runGRPC(addr string, opts []grpc.ServerOption) error {
 	}
 	grpcServer := grpc.NewServer(opts...)
 	profile.RegisterTenncorProfileServiceServer(grpcServer, a.server)
-	return graceful.Serve(listener, grpcServer)
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	reflection.Register(grpcServer)
+
+	stopHealth := make(chan struct{})
+	go watchReadiness(healthServer, a.store, stopHealth)
+	// grpc needs http2 without tls, which graceful's http server can't serve
+	graceful.PreHook(func() {
+		close(stopHealth)
+		healthServer.Shutdown()
+		grpcServer.GracefulStop()
+	})
+	return grpcServer.Serve(listener)
 }
 
 func (a *accretionAPI) runHTTP(httpAddr, grpcAddr string, muxOpts []runtime.ServeMuxOption, dialOpts []grpc.DialOption) error {
@@ -124,5 +204,15 @@ func (a *accretionAPI) runHTTP(httpAddr, grpcAddr string, muxOpts []runtime.Serv
 	if err != nil {
 		return err
 	}
+	upload := uploadHandler(mux, a.server, int64(a.cfg.MaxMessageBytes))
+	if err = mux.HandlePath(http.MethodPost, uploadPath, upload); err != nil {
+		return err
+	}
+	if err = mux.HandlePath(http.MethodGet, healthzPath, healthzHandler); err != nil {
+		return err
+	}
+	if err = mux.HandlePath(http.MethodGet, readyzPath, readyzHandler(a.store)); err != nil {
+		return err
+	}
 	return graceful.Serve(listener, mux)
 }
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+
+	"github.com/mingkaic/accretion/proto/profile"
+)
+
+// tensorServer records the tensor requests it receives
+type tensorServer struct {
+	profile.UnimplementedTenncorProfileServiceServer
+	req *profile.GetTensorDataRequest
+}
+
+func (s *tensorServer) GetTensorData(ctx context.Context,
+	req *profile.GetTensorDataRequest) (*profile.GetTensorDataResponse, error) {
+	s.req = req
+	return &profile.GetTensorDataResponse{}, nil
+}
+
+func TestGetTensorDataPath(t *testing.T) {
+	tests := []struct {
+		path   string
+		nodeId string
+	}{
+		{"/v1/profile/p/tensor/conv", "conv"},
+		{"/v1/profile/p/tensor/layer1/Conv", "layer1/Conv"},
+		{"/v1/profile/p/tensor//layer1/Conv", "/layer1/Conv"},
+		{"/v1/profile/p/tensor/%2Flayer1%2FConv", "/layer1/Conv"},
+		{"/v1/profile/p/tensor/%2Flayer1%2FConv?slice=0:2", "/layer1/Conv"},
+	}
+	for _, test := range tests {
+		server := &tensorServer{}
+		mux := runtime.NewServeMux()
+		if err := profile.RegisterTenncorProfileServiceHandlerServer(context.Background(), mux, server); err != nil {
+			t.Fatal(err)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, test.path, nil))
+		if w.Code != http.StatusOK {
+			t.Errorf("%s: got status %d: %s", test.path, w.Code, w.Body)
+			continue
+		}
+		if got := server.req.GetNodeId(); got != test.nodeId {
+			t.Errorf("%s: got node id %q, want %q", test.path, got, test.nodeId)
+		}
+		if got := server.req.GetProfileId(); got != "p" {
+			t.Errorf("%s: got profile id %q, want p", test.path, got)
+		}
+	}
+}
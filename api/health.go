@@ -0,0 +1,72 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/mingkaic/accretion/data"
+	"github.com/mingkaic/accretion/proto/profile"
+)
+
+const (
+	healthzPath = "/healthz"
+	readyzPath  = "/readyz"
+
+	// readinessInterval is how often the store is probed for grpc health
+	readinessInterval = 5 * time.Second
+	// readinessTimeout bounds each probe of the store
+	readinessTimeout = 2 * time.Second
+)
+
+// watchReadiness serves the profile service and the server overall
+// only while probes of store succeed, until stop closes
+func watchReadiness(healthServer *health.Server, store data.Store, stop <-chan struct{}) {
+	ticker := time.NewTicker(readinessInterval)
+	defer ticker.Stop()
+	var last error
+	for first := true; ; first = false {
+		ctx, cancel := context.WithTimeout(context.Background(), readinessTimeout)
+		err := store.Ready(ctx)
+		cancel()
+		status := healthpb.HealthCheckResponse_SERVING
+		if err != nil {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		if first || (err == nil) != (last == nil) {
+			log.Infof("Health is %s", status)
+		}
+		last = err
+		healthServer.SetServingStatus("", status)
+		healthServer.SetServingStatus(profile.TenncorProfileService_ServiceDesc.ServiceName, status)
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// healthzHandler reports the server is live whenever it can respond
+func healthzHandler(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	fmt.Fprintln(w, "ok")
+}
+
+// readyzHandler probes whether the store can serve requests
+func readyzHandler(store data.Store) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+		defer cancel()
+		if err := store.Ready(ctx); err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintln(w, "ok")
+	}
+}
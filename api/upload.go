@@ -0,0 +1,144 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/mingkaic/onnx_go/onnx"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/mingkaic/accretion/proto/profile"
+	"github.com/mingkaic/accretion/sidecar"
+)
+
+const (
+	uploadPath = "/v1/profiles"
+
+	// multipart parts beyond this are buffered on disk
+	uploadMemory = 32 << 20
+
+	modelField    = "model"
+	runtimesField = "runtimes"
+	nameField     = "name"
+	tagsField     = "tags"
+	pinnedField   = "pinned"
+)
+
+// uploadHandler creates a profile from a multipart upload of an onnx model
+// and an optional JSON or CSV sidecar of runtimes by node id,
+// through the same server as gRPC requests
+func uploadHandler(mux *runtime.ServeMux, server profile.TenncorProfileServiceServer,
+	maxBytes int64) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		_, outbound := runtime.MarshalerForRequest(mux, r)
+		// uploads are bounded like grpc messages, MaxBytesReader reads
+		// one byte past the limit before failing so body counts it
+		body := &countingReader{ReadCloser: r.Body}
+		r.Body = http.MaxBytesReader(w, body, maxBytes)
+		req, err := uploadRequest(r)
+		if body.n > maxBytes {
+			log.Debugf("profile upload exceeds %d bytes", maxBytes)
+			st := status.Newf(codes.ResourceExhausted, "upload is larger than %d bytes", maxBytes)
+			b, merr := outbound.Marshal(st.Proto())
+			if merr != nil {
+				runtime.HTTPError(r.Context(), mux, outbound, w, r, merr)
+				return
+			}
+			w.Header().Set("Content-Type", outbound.ContentType(st.Proto()))
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			w.Write(b)
+			return
+		}
+		if err != nil {
+			log.Debugf("bad profile upload: %v", err)
+			runtime.HTTPError(r.Context(), mux, outbound, w, r,
+				status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+		res, err := server.CreateProfile(r.Context(), req)
+		if err != nil {
+			runtime.HTTPError(r.Context(), mux, outbound, w, r, err)
+			return
+		}
+		b, err := outbound.Marshal(res)
+		if err != nil {
+			runtime.HTTPError(r.Context(), mux, outbound, w, r, err)
+			return
+		}
+		w.Header().Set("Content-Type", outbound.ContentType(res))
+		w.Write(b)
+	}
+}
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	io.ReadCloser
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+func uploadRequest(r *http.Request) (*profile.CreateProfileRequest, error) {
+	if err := r.ParseMultipartForm(uploadMemory); err != nil {
+		return nil, err
+	}
+	defer r.MultipartForm.RemoveAll()
+	modelFile, _, err := r.FormFile(modelField)
+	if err != nil {
+		return nil, fmt.Errorf("%s file: %v", modelField, err)
+	}
+	defer modelFile.Close()
+	b, err := ioutil.ReadAll(modelFile)
+	if err != nil {
+		return nil, err
+	}
+	model := &onnx.ModelProto{}
+	if err = proto.Unmarshal(b, model); err != nil {
+		return nil, fmt.Errorf("%s file is not an onnx model: %v", modelField, err)
+	}
+	req := &profile.CreateProfileRequest{
+		Model: model,
+		Name:  r.FormValue(nameField),
+	}
+	for _, tags := range r.MultipartForm.Value[tagsField] {
+		for _, tag := range strings.Split(tags, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				req.Tags = append(req.Tags, tag)
+			}
+		}
+	}
+	if pinned := r.FormValue(pinnedField); pinned != "" {
+		if req.Pinned, err = strconv.ParseBool(pinned); err != nil {
+			return nil, fmt.Errorf("%s: %v", pinnedField, err)
+		}
+	}
+	runtimesFile, header, err := r.FormFile(runtimesField)
+	if err == http.ErrMissingFile {
+		return req, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s file: %v", runtimesField, err)
+	}
+	defer runtimesFile.Close()
+	runtimes, err := sidecar.ReadRuntimes(header.Filename, runtimesFile)
+	if err != nil {
+		return nil, fmt.Errorf("%s file: %v", runtimesField, err)
+	}
+	req.OperatorData = make(map[string]*profile.FuncInfo, len(runtimes))
+	for id, nodeRuntime := range runtimes {
+		req.OperatorData[id] = &profile.FuncInfo{Runtime: nodeRuntime}
+	}
+	return req, nil
+}
@@ -0,0 +1,420 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mingkaic/onnx_go/onnx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/mingkaic/accretion/proto/profile"
+	"github.com/mingkaic/accretion/sidecar"
+)
+
+const timeFormat = "2006-01-02 15:04:05"
+
+func runList(ctx context.Context, cli profile.TenncorProfileServiceClient, args []string) error {
+	var (
+		tags  stringList
+		order string
+		all   bool
+		req   = &profile.ListProfileRequest{}
+	)
+	flags := commandFlags("list", "[flags]")
+	flags.Var(&tags, "tag", "Only list profiles with every tag, repeatable")
+	flags.StringVar(&req.NameContains, "name", "", "Only list profiles whose name contains this")
+	flags.StringVar(&req.ProducerName, "producer", "", "Only list profiles of this producer")
+	flags.StringVar(&order, "order", "created_at", "Order by created_at, node_count or total_runtime")
+	flags.BoolVar(&req.Ascending, "asc", false, "Order ascending rather than descending")
+	pageSize := flags.Int("page_size", 0, "Profiles per page, 0 is the server default")
+	flags.StringVar(&req.PageToken, "page_token", "", "Page to list from")
+	flags.BoolVar(&all, "all", false, "List every page")
+	if _, err := parseArgs(flags, args, 0); err != nil {
+		return err
+	}
+	orderBy, ok := profile.ProfileOrder_value[strings.ToUpper(order)]
+	if !ok {
+		return fmt.Errorf("unknown order %s", order)
+	}
+	req.Tags = tags
+	req.OrderBy = profile.ProfileOrder(orderBy)
+	req.PageSize = int32(*pageSize)
+
+	out := &profile.ListProfileResponse{}
+	for {
+		res, err := cli.ListProfile(ctx, req)
+		if err != nil {
+			return err
+		}
+		out.Profiles = append(out.Profiles, res.GetProfiles()...)
+		out.NextPageToken = res.GetNextPageToken()
+		if !all || out.NextPageToken == "" {
+			break
+		}
+		req.PageToken = out.NextPageToken
+	}
+	if asJSON {
+		return printProto(out)
+	}
+	rows := make([][]string, len(out.Profiles))
+	for i, summary := range out.Profiles {
+		rows[i] = []string{
+			summary.GetProfileId(),
+			summary.GetName(),
+			summary.GetModelName(),
+			summary.GetStatus(),
+			summary.GetCreatedAt().AsTime().Local().Format(timeFormat),
+			strconv.FormatUint(summary.GetNodeCount(), 10),
+			strconv.FormatUint(summary.GetTotalRuntime(), 10),
+			strconv.FormatBool(summary.GetPinned()),
+			strings.Join(summary.GetTags(), ","),
+		}
+	}
+	if err := printTable("ID\tNAME\tMODEL\tSTATUS\tCREATED\tNODES\tRUNTIME\tPINNED\tTAGS", rows); err != nil {
+		return err
+	}
+	if out.NextPageToken != "" {
+		fmt.Printf("\nnext page: -page_token %s\n", out.NextPageToken)
+	}
+	return nil
+}
+
+func runGet(ctx context.Context, cli profile.TenncorProfileServiceClient, args []string) error {
+	var collapse stringList
+	flags := commandFlags("get", "[flags] <profile_id>")
+	flags.Var(&collapse, "collapse", "Hide subgraphs of these control-flow nodes, repeatable")
+	collapseAll := flags.Bool("collapse_all", false, "Hide every subgraph")
+	args, err := parseArgs(flags, args, 1)
+	if err != nil {
+		return err
+	}
+	res, err := cli.GetProfile(ctx, &profile.GetProfileRequest{
+		ProfileId:   args[0],
+		Collapse:    collapse,
+		CollapseAll: *collapseAll,
+	})
+	if err != nil {
+		return err
+	}
+	if asJSON {
+		return printProto(res)
+	}
+	sections := make([][]string, len(res.GetSections()))
+	for i, section := range res.GetSections() {
+		sections[i] = []string{
+			section.GetName(),
+			strconv.FormatUint(section.GetNodeCount(), 10),
+			strconv.FormatUint(section.GetRuntime(), 10),
+		}
+	}
+	if err = printTable("SECTION\tNODES\tRUNTIME", sections); err != nil {
+		return err
+	}
+	fmt.Println()
+	nodes := make([][]string, len(res.GetNodes()))
+	for i, node := range res.GetNodes() {
+		nodes[i] = []string{
+			node.GetId(),
+			node.GetLabel(),
+			strings.ToLower(node.GetKind().String()),
+			node.GetSection(),
+			node.GetParent(),
+			strconv.FormatUint(node.GetRuntime(), 10),
+			node.GetDtype(),
+			fmt.Sprint(node.GetShape()),
+		}
+	}
+	return printTable("ID\tLABEL\tKIND\tSECTION\tPARENT\tRUNTIME\tDTYPE\tSHAPE", nodes)
+}
+
+func runCreate(ctx context.Context, cli profile.TenncorProfileServiceClient, args []string) error {
+	var (
+		tags stringList
+		req  = &profile.CreateProfileRequest{}
+	)
+	flags := commandFlags("create", "-model x.onnx [-runtimes r.json] [flags]")
+	modelFile := flags.String("model", "", "Onnx model file")
+	runtimesFile := flags.String("runtimes", "", "JSON object or CSV rows of runtimes by node id")
+	flags.StringVar(&req.Name, "name", "", "Name of the profile")
+	flags.Var(&tags, "tag", "Tag the profile, repeatable")
+	flags.BoolVar(&req.Pinned, "pinned", false, "Never collect the profile by retention")
+	stream := flags.Bool("stream", false, "Stream operator data for models too large for one message")
+	if _, err := parseArgs(flags, args, 0); err != nil {
+		return err
+	}
+	if *modelFile == "" {
+		flags.Usage()
+		return fmt.Errorf("create: missing -model")
+	}
+	b, err := ioutil.ReadFile(*modelFile)
+	if err != nil {
+		return err
+	}
+	req.Model = &onnx.ModelProto{}
+	if err = proto.Unmarshal(b, req.Model); err != nil {
+		return fmt.Errorf("%s is not an onnx model: %v", *modelFile, err)
+	}
+	req.Tags = tags
+	if *runtimesFile != "" {
+		runtimes, err := readRuntimes(*runtimesFile)
+		if err != nil {
+			return err
+		}
+		req.OperatorData = make(map[string]*profile.FuncInfo, len(runtimes))
+		for id, runtime := range runtimes {
+			req.OperatorData[id] = &profile.FuncInfo{Runtime: runtime}
+		}
+	}
+	var res *profile.CreateProfileResponse
+	if *stream {
+		res, err = streamCreate(ctx, cli, req)
+	} else {
+		res, err = cli.CreateProfile(ctx, req)
+	}
+	if err != nil {
+		return err
+	}
+	if asJSON {
+		return printProto(res)
+	}
+	fmt.Println(res.GetProfileId())
+	return nil
+}
+
+// streamCreate sends the request without operator data or initializer tensors,
+// then operator data one node at a time
+func streamCreate(ctx context.Context, cli profile.TenncorProfileServiceClient,
+	req *profile.CreateProfileRequest) (*profile.CreateProfileResponse, error) {
+	stream, err := cli.StreamCreateProfile(ctx)
+	if err != nil {
+		return nil, err
+	}
+	opData := stripInitializers(req.GetModel(), req.OperatorData)
+	req.OperatorData = nil
+	if err = stream.Send(&profile.StreamCreateProfileRequest{
+		Chunk: &profile.StreamCreateProfileRequest_Header{Header: req},
+	}); err != nil && err != io.EOF {
+		return nil, err
+	}
+sending:
+	for id, op := range opData {
+		for _, chunk := range opChunks(id, op) {
+			// the server closes the stream early when it fails, reported by CloseAndRecv
+			if err = stream.Send(chunk); err == io.EOF {
+				break sending
+			} else if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return stream.CloseAndRecv()
+}
+
+// partOverhead bounds the bytes of a tensor part message besides its raw data and node id
+const partOverhead = 64
+
+// opChunks are the messages streaming op of node id, dense raw data too large
+// for one message is sent as tensor parts after the tensor's type and dims
+func opChunks(id string, op *profile.FuncInfo) []*profile.StreamCreateProfileRequest {
+	opChunk := func(op *profile.FuncInfo) *profile.StreamCreateProfileRequest {
+		return &profile.StreamCreateProfileRequest{
+			Chunk: &profile.StreamCreateProfileRequest_OperatorData{
+				OperatorData: &profile.OperatorDataChunk{
+					OperatorData: map[string]*profile.FuncInfo{id: op},
+				},
+			},
+		}
+	}
+	whole := opChunk(op)
+	dense := op.GetDenseData()
+	raw := dense.GetRawData()
+	partBytes := maxMessageBytes - len(id) - partOverhead
+	if len(raw) == 0 || partBytes <= 0 || proto.Size(whole) <= maxMessageBytes {
+		return []*profile.StreamCreateProfileRequest{whole}
+	}
+	chunks := []*profile.StreamCreateProfileRequest{opChunk(&profile.FuncInfo{
+		Data:    &profile.FuncInfo_DenseData{DenseData: strippedTensor(dense)},
+		Runtime: op.GetRuntime(),
+	})}
+	for offset := 0; offset < len(raw); offset += partBytes {
+		end := offset + partBytes
+		if end > len(raw) {
+			end = len(raw)
+		}
+		chunks = append(chunks, &profile.StreamCreateProfileRequest{
+			Chunk: &profile.StreamCreateProfileRequest_TensorPart{
+				TensorPart: &profile.TensorPart{
+					NodeId:  id,
+					Offset:  uint64(offset),
+					RawData: raw[offset:end],
+				},
+			},
+		})
+	}
+	return chunks
+}
+
+// stripInitializers moves the tensors of initializers in every graph of model
+// into opData, leaving their names, types and dims in the graphs
+func stripInitializers(model *onnx.ModelProto, opData map[string]*profile.FuncInfo) map[string]*profile.FuncInfo {
+	if opData == nil {
+		opData = make(map[string]*profile.FuncInfo)
+	}
+	opInfo := func(id string) *profile.FuncInfo {
+		op, ok := opData[id]
+		if !ok {
+			op = &profile.FuncInfo{}
+			opData[id] = op
+		}
+		return op
+	}
+	graphs := []*onnx.GraphProto{model.GetGraph()}
+	for _, info := range model.GetTrainingInfo() {
+		graphs = append(graphs, info.GetInitialization(), info.GetAlgorithm())
+	}
+	for len(graphs) > 0 {
+		graph := graphs[len(graphs)-1]
+		graphs = graphs[:len(graphs)-1]
+		if graph == nil {
+			continue
+		}
+		for i, init := range graph.GetInitializer() {
+			opInfo(init.GetName()).Data = &profile.FuncInfo_DenseData{DenseData: init}
+			graph.Initializer[i] = strippedTensor(init)
+		}
+		for i, init := range graph.GetSparseInitializer() {
+			opInfo(init.GetValues().GetName()).Data = &profile.FuncInfo_SparseData{SparseData: init}
+			graph.SparseInitializer[i] = &onnx.SparseTensorProto{
+				Values: strippedTensor(init.GetValues()),
+				Dims:   init.GetDims(),
+			}
+		}
+		// control-flow subgraphs hold initializers of their own
+		for _, node := range graph.GetNode() {
+			for _, attr := range node.GetAttribute() {
+				if g := attr.GetG(); g != nil {
+					graphs = append(graphs, g)
+				}
+				graphs = append(graphs, attr.GetGraphs()...)
+			}
+		}
+	}
+	return opData
+}
+
+func strippedTensor(tensor *onnx.TensorProto) *onnx.TensorProto {
+	return &onnx.TensorProto{
+		Name:      tensor.GetName(),
+		DataType:  tensor.GetDataType(),
+		Dims:      tensor.GetDims(),
+		DocString: tensor.GetDocString(),
+	}
+}
+
+func readRuntimes(fname string) (map[string]uint64, error) {
+	f, err := os.Open(fname)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	runtimes, err := sidecar.ReadRuntimes(fname, f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", fname, err)
+	}
+	return runtimes, nil
+}
+
+func runDelete(ctx context.Context, cli profile.TenncorProfileServiceClient, args []string) error {
+	args, err := parseArgs(commandFlags("delete", "<profile_id>..."), args, -1)
+	if err != nil {
+		return err
+	}
+	var (
+		deleted = make([]*profile.DeleteProfileResponse, len(args))
+		rows    = make([][]string, len(args))
+	)
+	for i, profileId := range args {
+		if deleted[i], err = cli.DeleteProfile(ctx, &profile.DeleteProfileRequest{ProfileId: profileId}); err != nil {
+			return err
+		}
+		rows[i] = []string{
+			profileId,
+			strconv.FormatUint(deleted[i].GetNodesDeleted(), 10),
+			strconv.FormatUint(deleted[i].GetBlobsDeleted(), 10),
+			strconv.FormatUint(deleted[i].GetBlobBytesDeleted(), 10),
+		}
+	}
+	if asJSON {
+		out := make([]json.RawMessage, len(deleted))
+		for i, res := range deleted {
+			if out[i], err = protojson.Marshal(res); err != nil {
+				return err
+			}
+		}
+		return printJSON(os.Stdout, out)
+	}
+	return printTable("ID\tNODES\tBLOBS\tBYTES", rows)
+}
+
+func runExport(ctx context.Context, cli profile.TenncorProfileServiceClient, args []string) error {
+	flags := commandFlags("export", "[flags] <profile_id>")
+	outFile := flags.String("out", "", "File to write, stdout when empty")
+	tensors := flags.Bool("tensors", true, "Include the tensor data of every node")
+	maxElements := flags.Uint64("max_elements", 0, "Truncate tensors to this many elements, 0 is unlimited")
+	args, err := parseArgs(flags, args, 1)
+	if err != nil {
+		return err
+	}
+	profileId := args[0]
+	res, err := cli.GetProfile(ctx, &profile.GetProfileRequest{ProfileId: profileId})
+	if err != nil {
+		return err
+	}
+	export := struct {
+		ProfileId  string                     `json:"profileId"`
+		ExportedAt time.Time                  `json:"exportedAt"`
+		Profile    json.RawMessage            `json:"profile"`
+		Tensors    map[string]json.RawMessage `json:"tensors,omitempty"`
+	}{ProfileId: profileId, ExportedAt: time.Now().UTC()}
+	if export.Profile, err = protojson.Marshal(res); err != nil {
+		return err
+	}
+	if *tensors {
+		export.Tensors = make(map[string]json.RawMessage, len(res.GetNodes()))
+		for _, node := range res.GetNodes() {
+			tensor, err := cli.GetTensorData(ctx, &profile.GetTensorDataRequest{
+				ProfileId:   profileId,
+				NodeId:      node.GetId(),
+				MaxElements: *maxElements,
+			})
+			if status.Code(err) == codes.NotFound {
+				// nodes such as operators hold no tensor
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("tensor %s: %v", node.GetId(), err)
+			}
+			if export.Tensors[node.GetId()], err = protojson.Marshal(tensor); err != nil {
+				return err
+			}
+		}
+	}
+	out := os.Stdout
+	if *outFile != "" {
+		if out, err = os.Create(*outFile); err != nil {
+			return err
+		}
+		defer out.Close()
+	}
+	return printJSON(out, export)
+}
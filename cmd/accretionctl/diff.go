@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/mingkaic/accretion/proto/profile"
+)
+
+type (
+	// runtimeDiff compares a node or section between two profiles,
+	// a missing node has no runtime in its profile
+	runtimeDiff struct {
+		Id      string  `json:"id"`
+		Label   string  `json:"label,omitempty"`
+		Section string  `json:"section,omitempty"`
+		Before  *uint64 `json:"before,omitempty"`
+		After   *uint64 `json:"after,omitempty"`
+		Delta   int64   `json:"delta"`
+	}
+)
+
+func runDiff(ctx context.Context, cli profile.TenncorProfileServiceClient, args []string) error {
+	flags := commandFlags("diff", "[flags] <profile_id> <profile_id>")
+	changedOnly := flags.Bool("changed", false, "Only show nodes whose runtime changed")
+	args, err := parseArgs(flags, args, 2)
+	if err != nil {
+		return err
+	}
+	profiles := make([]*profile.GetProfileResponse, len(args))
+	for i, profileId := range args {
+		if profiles[i], err = cli.GetProfile(ctx, &profile.GetProfileRequest{ProfileId: profileId}); err != nil {
+			return err
+		}
+	}
+	before, after := profiles[0], profiles[1]
+	var (
+		nodes    = diffNodes(before.GetNodes(), after.GetNodes(), *changedOnly)
+		sections = diffSections(before.GetSections(), after.GetSections())
+	)
+	if asJSON {
+		return printJSON(os.Stdout, struct {
+			Before   string         `json:"before"`
+			After    string         `json:"after"`
+			Sections []*runtimeDiff `json:"sections"`
+			Nodes    []*runtimeDiff `json:"nodes"`
+		}{args[0], args[1], sections, nodes})
+	}
+	rows := make([][]string, len(sections))
+	for i, diff := range sections {
+		rows[i] = diff.row(false)
+	}
+	if err = printTable("SECTION\tBEFORE\tAFTER\tDELTA", rows); err != nil {
+		return err
+	}
+	os.Stdout.WriteString("\n")
+	rows = make([][]string, len(nodes))
+	for i, diff := range nodes {
+		rows[i] = diff.row(true)
+	}
+	return printTable("ID\tLABEL\tSECTION\tBEFORE\tAFTER\tDELTA", rows)
+}
+
+// diffNodes pairs nodes by id, largest runtime change first
+func diffNodes(before, after []*profile.SigmaNode, changedOnly bool) []*runtimeDiff {
+	byId := make(map[string]*runtimeDiff)
+	var diffs []*runtimeDiff
+	for i, nodes := range [][]*profile.SigmaNode{before, after} {
+		for _, node := range nodes {
+			diff, ok := byId[node.GetId()]
+			if !ok {
+				diff = &runtimeDiff{
+					Id:      node.GetId(),
+					Label:   node.GetLabel(),
+					Section: node.GetSection(),
+				}
+				byId[node.GetId()] = diff
+				diffs = append(diffs, diff)
+			}
+			runtime := node.GetRuntime()
+			if i == 0 {
+				diff.Before = &runtime
+			} else {
+				diff.After = &runtime
+			}
+		}
+	}
+	out := diffs[:0]
+	for _, diff := range diffs {
+		diff.Delta = diff.delta()
+		if !changedOnly || diff.Delta != 0 || diff.Before == nil || diff.After == nil {
+			out = append(out, diff)
+		}
+	}
+	sortDiffs(out)
+	return out
+}
+
+func diffSections(before, after []*profile.ProfileSection) []*runtimeDiff {
+	byName := make(map[string]*runtimeDiff)
+	var diffs []*runtimeDiff
+	for i, sections := range [][]*profile.ProfileSection{before, after} {
+		for _, section := range sections {
+			diff, ok := byName[section.GetName()]
+			if !ok {
+				diff = &runtimeDiff{Id: section.GetName()}
+				byName[section.GetName()] = diff
+				diffs = append(diffs, diff)
+			}
+			runtime := section.GetRuntime()
+			if i == 0 {
+				diff.Before = &runtime
+			} else {
+				diff.After = &runtime
+			}
+		}
+	}
+	for _, diff := range diffs {
+		diff.Delta = diff.delta()
+	}
+	return diffs
+}
+
+func (diff *runtimeDiff) delta() int64 {
+	var before, after int64
+	if diff.Before != nil {
+		before = int64(*diff.Before)
+	}
+	if diff.After != nil {
+		after = int64(*diff.After)
+	}
+	return after - before
+}
+
+func (diff *runtimeDiff) row(withNode bool) []string {
+	runtimeString := func(runtime *uint64) string {
+		if runtime == nil {
+			return "-"
+		}
+		return strconv.FormatUint(*runtime, 10)
+	}
+	delta := strconv.FormatInt(diff.Delta, 10)
+	if diff.Delta > 0 {
+		delta = "+" + delta
+	}
+	row := []string{diff.Id}
+	if withNode {
+		row = append(row, diff.Label, diff.Section)
+	}
+	return append(row, runtimeString(diff.Before), runtimeString(diff.After), delta)
+}
+
+func sortDiffs(diffs []*runtimeDiff) {
+	abs := func(v int64) int64 {
+		if v < 0 {
+			return -v
+		}
+		return v
+	}
+	sort.SliceStable(diffs, func(i, j int) bool {
+		if a, b := abs(diffs[i].Delta), abs(diffs[j].Delta); a != b {
+			return a > b
+		}
+		return diffs[i].Id < diffs[j].Id
+	})
+}
@@ -0,0 +1,163 @@
+// accretionctl is a command-line client of the accretion profile service
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/mingkaic/accretion/proto/profile"
+)
+
+type (
+	command struct {
+		summary string
+		run     func(ctx context.Context, cli profile.TenncorProfileServiceClient, args []string) error
+	}
+
+	// stringList is a flag that can be repeated or comma separated
+	stringList []string
+)
+
+var (
+	addr            string
+	timeout         time.Duration
+	asJSON          bool
+	maxMessageBytes int
+
+	commands = map[string]command{
+		"list":   {"List profiles", runList},
+		"get":    {"Show a profile's sections and nodes", runGet},
+		"create": {"Create a profile from an onnx model", runCreate},
+		"delete": {"Delete profiles", runDelete},
+		"export": {"Write a profile and its tensors as JSON", runExport},
+		"diff":   {"Compare node runtimes of two profiles", runDiff},
+	}
+)
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			*l = append(*l, v)
+		}
+	}
+	return nil
+}
+
+func main() {
+	flag.StringVar(&addr, "addr", "localhost:8069", "Address of the accretion grpc server")
+	flag.DurationVar(&timeout, "timeout", time.Minute, "Timeout of each command")
+	flag.BoolVar(&asJSON, "json", false, "Print JSON instead of tables")
+	// the default matches the server's default max_message_bytes
+	flag.IntVar(&maxMessageBytes, "max_message_bytes", 64<<20, "Largest grpc message received, streamed tensors are split into parts below it")
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+	name := flag.Arg(0)
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %s\n", name)
+		usage()
+		os.Exit(2)
+	}
+	conn, err := grpc.Dial(addr, grpc.WithInsecure(),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxMessageBytes)))
+	if err != nil {
+		fatal(err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err = cmd.run(ctx, profile.NewTenncorProfileServiceClient(conn), flag.Args()[1:]); err != nil {
+		fatal(err)
+	}
+}
+
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintf(out, "usage: accretionctl [flags] <command> [command flags] [args]\n\ncommands:\n")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(out, "  %-8s %s\n", name, commands[name].summary)
+	}
+	fmt.Fprintf(out, "\nflags:\n")
+	flag.PrintDefaults()
+}
+
+func fatal(err error) {
+	fmt.Fprintf(os.Stderr, "accretionctl: %v\n", err)
+	os.Exit(1)
+}
+
+// commandFlags are the flags of command name taking args, every command accepts -json
+func commandFlags(name, args string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ExitOnError)
+	flags.BoolVar(&asJSON, "json", asJSON, "Print JSON instead of tables")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "usage: accretionctl %s %s\n", name, args)
+		flags.PrintDefaults()
+	}
+	return flags
+}
+
+// parseArgs parses args of a command expecting nargs positional args,
+// a negative nargs expects at least one
+func parseArgs(flags *flag.FlagSet, args []string, nargs int) ([]string, error) {
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+	if (nargs < 0 && flags.NArg() == 0) || (nargs >= 0 && flags.NArg() != nargs) {
+		flags.Usage()
+		return nil, fmt.Errorf("%s: wrong number of arguments", flags.Name())
+	}
+	return flags.Args(), nil
+}
+
+func printProto(msg proto.Message) error {
+	b, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Println(string(b))
+	return err
+}
+
+func printJSON(out io.Writer, v interface{}) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// printTable writes tab separated rows under header as aligned columns
+func printTable(header string, rows [][]string) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, header)
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
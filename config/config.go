@@ -0,0 +1,224 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	DgraphBackend   = "dgraph"
+	EmbeddedBackend = "embedded"
+
+	// EnvPrefix prefixes the environment variable of every flag,
+	// such that -grpc_addr is also set by ACCRETION_GRPC_ADDR
+	EnvPrefix = "ACCRETION_"
+)
+
+type (
+	// Config configures the accretion server, each setting is taken from
+	// the first of flags, environment variables, the config file and defaults
+	Config struct {
+		LogLevel        string    `yaml:"log_level" toml:"log_level"`
+		GRPCAddr        string    `yaml:"grpc_addr" toml:"grpc_addr"`
+		HTTPAddr        string    `yaml:"http_addr" toml:"http_addr"`
+		MaxMessageBytes int       `yaml:"max_message_bytes" toml:"max_message_bytes"`
+		Store           string    `yaml:"store" toml:"store"`
+		DataDir         string    `yaml:"data_dir" toml:"data_dir"`
+		Dgraph          Dgraph    `yaml:"dgraph" toml:"dgraph"`
+		Retention       Retention `yaml:"retention" toml:"retention"`
+	}
+
+	// Dgraph configures the dgraph backend
+	Dgraph struct {
+		Url        string `yaml:"url" toml:"url"`
+		User       string `yaml:"user" toml:"user"`
+		Password   string `yaml:"password" toml:"password"`
+		EnableAcl  bool   `yaml:"enable_acl" toml:"enable_acl"`
+		SchemaFile string `yaml:"schema_file" toml:"schema_file"`
+		// BlobDir is where tensor blobs of dgraph profiles are stored
+		BlobDir string `yaml:"blob_dir" toml:"blob_dir"`
+	}
+
+	// Retention bounds which profiles are kept, zero values are unbounded
+	Retention struct {
+		MaxAge              time.Duration `yaml:"max_age" toml:"max_age"`
+		MaxProfilesPerModel int           `yaml:"max_per_model" toml:"max_per_model"`
+		MaxBlobBytes        int64         `yaml:"max_blob_bytes" toml:"max_blob_bytes"`
+		GCInterval          time.Duration `yaml:"gc_interval" toml:"gc_interval"`
+	}
+)
+
+func Default() Config {
+	return Config{
+		LogLevel:        "debug",
+		GRPCAddr:        "localhost:8069",
+		HTTPAddr:        "localhost:8071",
+		MaxMessageBytes: 64 << 20,
+		Store:           DgraphBackend,
+		DataDir:         "accretion_data",
+		Dgraph: Dgraph{
+			Url:        "127.0.0.1:9080",
+			User:       "groot",
+			Password:   "password",
+			SchemaFile: "data/schema.dql",
+			BlobDir:    "blobs",
+		},
+		Retention: Retention{
+			GCInterval: time.Hour,
+		},
+	}
+}
+
+// Load reads the config from command line args, ACCRETION_ environment
+// variables and the file named by -config or ACCRETION_CONFIG in that precedence
+func Load(name string, args []string) (Config, error) {
+	var (
+		cfg        = Default()
+		configFile string
+		flags      = flag.NewFlagSet(name, flag.ExitOnError)
+	)
+	flags.StringVar(&configFile, "config", "", "YAML or TOML config file")
+	flags.StringVar(&cfg.LogLevel, "log_level", cfg.LogLevel, "Log level")
+	flags.StringVar(&cfg.GRPCAddr, "grpc_addr", cfg.GRPCAddr, "Address serving grpc")
+	flags.StringVar(&cfg.HTTPAddr, "http_addr", cfg.HTTPAddr, "Address serving the http gateway")
+	flags.IntVar(&cfg.MaxMessageBytes, "max_message_bytes", cfg.MaxMessageBytes,
+		"Largest grpc message received, stream larger profiles")
+	flags.StringVar(&cfg.Store, "store", cfg.Store,
+		"Storage backend, one of dgraph or embedded")
+	flags.StringVar(&cfg.DataDir, "data_dir", cfg.DataDir,
+		"Directory where the embedded backend persists profiles")
+	flags.StringVar(&cfg.Dgraph.Url, "dgraph_url", cfg.Dgraph.Url, "Address of the dgraph alpha")
+	flags.StringVar(&cfg.Dgraph.User, "dgraph_user", cfg.Dgraph.User, "Dgraph user when acl is enabled")
+	flags.StringVar(&cfg.Dgraph.Password, "dgraph_password", cfg.Dgraph.Password,
+		"Dgraph password when acl is enabled")
+	flags.BoolVar(&cfg.Dgraph.EnableAcl, "dgraph_enable_acl", cfg.Dgraph.EnableAcl,
+		"Log into dgraph, which requires acl enterprise features")
+	flags.StringVar(&cfg.Dgraph.SchemaFile, "dgraph_schema_file", cfg.Dgraph.SchemaFile,
+		"Schema published to dgraph")
+	flags.StringVar(&cfg.Dgraph.BlobDir, "dgraph_blob_dir", cfg.Dgraph.BlobDir,
+		"Directory where the dgraph backend stores tensor blobs")
+	flags.DurationVar(&cfg.Retention.MaxAge, "retention_max_age", cfg.Retention.MaxAge,
+		"Collect unpinned profiles older than this, 0 keeps profiles forever")
+	flags.IntVar(&cfg.Retention.MaxProfilesPerModel, "retention_max_per_model", cfg.Retention.MaxProfilesPerModel,
+		"Collect the oldest unpinned profiles beyond this many per model, 0 is unbounded")
+	flags.Int64Var(&cfg.Retention.MaxBlobBytes, "retention_max_blob_bytes", cfg.Retention.MaxBlobBytes,
+		"Collect the oldest unpinned profiles while blobs exceed this many bytes, 0 is unbounded")
+	flags.DurationVar(&cfg.Retention.GCInterval, "gc_interval", cfg.Retention.GCInterval,
+		"How often retention is enforced")
+	if err := flags.Parse(args); err != nil {
+		return cfg, err
+	}
+
+	// flags were parsed into cfg, so they are reapplied over the file and environment
+	set := make(map[string]string)
+	flags.Visit(func(f *flag.Flag) {
+		set[f.Name] = f.Value.String()
+	})
+	if _, ok := set["config"]; !ok {
+		configFile = os.Getenv(EnvPrefix + "CONFIG")
+	}
+	cfg = Default()
+	if configFile != "" {
+		if err := cfg.readFile(configFile); err != nil {
+			return cfg, fmt.Errorf("config %s: %v", configFile, err)
+		}
+	}
+	var errs []string
+	flags.VisitAll(func(f *flag.Flag) {
+		env := EnvPrefix + strings.ToUpper(f.Name)
+		value, ok := os.LookupEnv(env)
+		if !ok || f.Name == "config" {
+			return
+		}
+		if err := f.Value.Set(value); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", env, err))
+		}
+	})
+	if len(errs) > 0 {
+		return cfg, errors.New(strings.Join(errs, "; "))
+	}
+	for name, value := range set {
+		if err := flags.Set(name, value); err != nil {
+			return cfg, err
+		}
+	}
+	return cfg, cfg.Validate()
+}
+
+// readFile decodes a YAML or TOML file over cfg by its extension
+func (cfg *Config) readFile(fname string) error {
+	b, err := ioutil.ReadFile(fname)
+	if err != nil {
+		return err
+	}
+	switch strings.ToLower(path.Ext(fname)) {
+	case ".yaml", ".yml":
+		return yaml.Unmarshal(b, cfg)
+	case ".toml":
+		return toml.Unmarshal(b, cfg)
+	}
+	return fmt.Errorf("unknown config format %s, expecting .yaml, .yml or .toml", path.Ext(fname))
+}
+
+// Validate reports every invalid setting
+func (cfg Config) Validate() error {
+	var errs []string
+	if _, err := log.ParseLevel(cfg.LogLevel); err != nil {
+		errs = append(errs, fmt.Sprintf("log_level: %v", err))
+	}
+	if _, _, err := net.SplitHostPort(cfg.GRPCAddr); err != nil {
+		errs = append(errs, fmt.Sprintf("grpc_addr: %v", err))
+	}
+	if _, _, err := net.SplitHostPort(cfg.HTTPAddr); err != nil {
+		errs = append(errs, fmt.Sprintf("http_addr: %v", err))
+	}
+	if cfg.GRPCAddr == cfg.HTTPAddr {
+		errs = append(errs, "grpc_addr and http_addr must differ")
+	}
+	if cfg.MaxMessageBytes <= 0 {
+		errs = append(errs, "max_message_bytes must be positive")
+	}
+	switch cfg.Store {
+	case DgraphBackend:
+		if cfg.Dgraph.Url == "" {
+			errs = append(errs, "dgraph_url is required by the dgraph backend")
+		}
+		if cfg.Dgraph.SchemaFile == "" {
+			errs = append(errs, "dgraph_schema_file is required by the dgraph backend")
+		} else if info, err := os.Stat(cfg.Dgraph.SchemaFile); err != nil {
+			errs = append(errs, fmt.Sprintf("dgraph_schema_file: %v", err))
+		} else if info.IsDir() {
+			errs = append(errs, fmt.Sprintf("dgraph_schema_file: %s is a directory", cfg.Dgraph.SchemaFile))
+		}
+		if cfg.Dgraph.BlobDir == "" {
+			errs = append(errs, "dgraph_blob_dir is required by the dgraph backend")
+		}
+	case EmbeddedBackend:
+		if cfg.DataDir == "" {
+			errs = append(errs, "data_dir is required by the embedded backend")
+		}
+	default:
+		errs = append(errs, fmt.Sprintf("store: unknown storage backend %s", cfg.Store))
+	}
+	if cfg.Retention.MaxAge < 0 || cfg.Retention.MaxProfilesPerModel < 0 || cfg.Retention.MaxBlobBytes < 0 {
+		errs = append(errs, "retention limits must not be negative")
+	}
+	if cfg.Retention.GCInterval <= 0 {
+		errs = append(errs, "gc_interval must be positive")
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid config: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
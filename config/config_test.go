@@ -0,0 +1,271 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// setenv sets environment variables for the duration of the test
+func setenv(t *testing.T, env map[string]string) {
+	for key, value := range env {
+		key := key
+		old, had := os.LookupEnv(key)
+		os.Setenv(key, value)
+		t.Cleanup(func() {
+			if had {
+				os.Setenv(key, old)
+			} else {
+				os.Unsetenv(key)
+			}
+		})
+	}
+}
+
+// writeFile writes content under dir, returning its path
+func writeFile(t *testing.T, dir, name, content string) string {
+	fname := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(fname, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return fname
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	schema := writeFile(t, dir, "schema.dql", "id: string @index(exact) .")
+	yamlFile := writeFile(t, dir, "accretion.yaml", `
+grpc_addr: file:1
+http_addr: file:2
+log_level: warn
+store: embedded
+data_dir: file_data
+retention:
+  max_age: 720h
+  gc_interval: 10m
+`)
+	tomlFile := writeFile(t, dir, "accretion.toml", `
+grpc_addr = "file:1"
+http_addr = "file:2"
+log_level = "warn"
+store = "embedded"
+data_dir = "file_data"
+
+[retention]
+max_age = "720h"
+gc_interval = "10m"
+`)
+	tests := []struct {
+		name string
+		args []string
+		env  map[string]string
+		want func(Config) Config
+	}{
+		{
+			name: "defaults",
+			args: []string{"-dgraph_schema_file", schema},
+			want: func(cfg Config) Config {
+				cfg.Dgraph.SchemaFile = schema
+				return cfg
+			},
+		},
+		{
+			name: "yaml file over defaults",
+			args: []string{"-config", yamlFile},
+			want: fromFile,
+		},
+		{
+			name: "toml file over defaults",
+			args: []string{"-config", tomlFile},
+			want: fromFile,
+		},
+		{
+			name: "file named by environment",
+			env:  map[string]string{EnvPrefix + "CONFIG": yamlFile},
+			want: fromFile,
+		},
+		{
+			name: "config flag over environment",
+			args: []string{"-config", tomlFile},
+			env:  map[string]string{EnvPrefix + "CONFIG": filepath.Join(dir, "missing.yaml")},
+			want: fromFile,
+		},
+		{
+			name: "environment over file",
+			args: []string{"-config", yamlFile},
+			env: map[string]string{
+				EnvPrefix + "GRPC_ADDR":         "env:1",
+				EnvPrefix + "RETENTION_MAX_AGE": "1h",
+				EnvPrefix + "DGRAPH_ENABLE_ACL": "true",
+			},
+			want: func(cfg Config) Config {
+				cfg = fromFile(cfg)
+				cfg.GRPCAddr = "env:1"
+				cfg.Retention.MaxAge = time.Hour
+				cfg.Dgraph.EnableAcl = true
+				return cfg
+			},
+		},
+		{
+			name: "flags over environment and file",
+			args: []string{"-config", yamlFile, "-grpc_addr", "flag:1", "-gc_interval", "1s"},
+			env: map[string]string{
+				EnvPrefix + "GRPC_ADDR":   "env:1",
+				EnvPrefix + "HTTP_ADDR":   "env:2",
+				EnvPrefix + "GC_INTERVAL": "1m",
+			},
+			want: func(cfg Config) Config {
+				cfg = fromFile(cfg)
+				cfg.GRPCAddr = "flag:1"
+				cfg.HTTPAddr = "env:2"
+				cfg.Retention.GCInterval = time.Second
+				return cfg
+			},
+		},
+		{
+			name: "flags set to their defaults still override",
+			args: []string{"-config", yamlFile, "-store", DgraphBackend, "-dgraph_schema_file", schema},
+			env:  map[string]string{EnvPrefix + "STORE": EmbeddedBackend},
+			want: func(cfg Config) Config {
+				cfg = fromFile(cfg)
+				cfg.Store = DgraphBackend
+				cfg.Dgraph.SchemaFile = schema
+				return cfg
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			setenv(t, test.env)
+			got, err := Load("accretion", test.args)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if want := test.want(Default()); got != want {
+				t.Errorf("loaded\n%+v\nwant\n%+v", got, want)
+			}
+		})
+	}
+}
+
+// fromFile is cfg with the settings of the test config files
+func fromFile(cfg Config) Config {
+	cfg.GRPCAddr = "file:1"
+	cfg.HTTPAddr = "file:2"
+	cfg.LogLevel = "warn"
+	cfg.Store = EmbeddedBackend
+	cfg.DataDir = "file_data"
+	cfg.Retention.MaxAge = 720 * time.Hour
+	cfg.Retention.GCInterval = 10 * time.Minute
+	return cfg
+}
+
+func TestLoadInvalid(t *testing.T) {
+	dir := t.TempDir()
+	embedded := []string{"-store", EmbeddedBackend}
+	tests := []struct {
+		name string
+		args []string
+		env  map[string]string
+		file string
+		want string
+	}{
+		{
+			name: "unknown log level",
+			args: append(embedded, "-log_level", "loud"),
+			want: "log_level",
+		},
+		{
+			name: "address without port",
+			args: append(embedded, "-grpc_addr", "localhost"),
+			want: "grpc_addr",
+		},
+		{
+			name: "shared address",
+			args: append(embedded, "-grpc_addr", "localhost:1", "-http_addr", "localhost:1"),
+			want: "grpc_addr and http_addr must differ",
+		},
+		{
+			name: "no message size",
+			args: append(embedded, "-max_message_bytes", "0"),
+			want: "max_message_bytes must be positive",
+		},
+		{
+			name: "unknown store",
+			args: []string{"-store", "postgres"},
+			want: "unknown storage backend postgres",
+		},
+		{
+			name: "embedded store without directory",
+			args: append(embedded, "-data_dir", ""),
+			want: "data_dir is required",
+		},
+		{
+			name: "missing schema file",
+			args: []string{"-dgraph_schema_file", filepath.Join(dir, "missing.dql")},
+			want: "dgraph_schema_file",
+		},
+		{
+			name: "schema file is a directory",
+			args: []string{"-dgraph_schema_file", dir},
+			want: "is a directory",
+		},
+		{
+			name: "dgraph store without blob directory",
+			args: []string{"-dgraph_schema_file", writeFile(t, dir, "schema.dql", "id: string ."), "-dgraph_blob_dir", ""},
+			want: "dgraph_blob_dir is required",
+		},
+		{
+			name: "negative retention",
+			args: append(embedded, "-retention_max_per_model", "-1"),
+			want: "retention limits must not be negative",
+		},
+		{
+			name: "no gc interval",
+			args: append(embedded, "-gc_interval", "0s"),
+			want: "gc_interval must be positive",
+		},
+		{
+			name: "bad environment value",
+			args: embedded,
+			env:  map[string]string{EnvPrefix + "MAX_MESSAGE_BYTES": "lots"},
+			want: EnvPrefix + "MAX_MESSAGE_BYTES",
+		},
+		{
+			name: "invalid file setting",
+			file: writeFile(t, dir, "invalid.yaml", "store: embedded\nretention:\n  gc_interval: -1m\n"),
+			want: "gc_interval must be positive",
+		},
+		{
+			name: "bad file duration",
+			file: writeFile(t, dir, "duration.toml", "[retention]\nmax_age = \"forever\"\n"),
+			want: "duration.toml",
+		},
+		{
+			name: "unknown file format",
+			file: writeFile(t, dir, "accretion.json", "{}"),
+			want: "unknown config format .json",
+		},
+		{
+			name: "missing file",
+			file: filepath.Join(dir, "missing.yaml"),
+			want: "missing.yaml",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			setenv(t, test.env)
+			args := test.args
+			if test.file != "" {
+				args = append([]string{"-config", test.file}, args...)
+			}
+			_, err := Load("accretion", args)
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Errorf("got error %v, want %q", err, test.want)
+			}
+		})
+	}
+}
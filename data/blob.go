@@ -1,50 +1,251 @@
 package data
 
 import (
+	"errors"
 	"fmt"
 	"io/ioutil"
+	"net/url"
 	"os"
 	"path"
-	"sync"
+	"strings"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/mingkaic/accretion/proto/storage"
 	log "github.com/sirupsen/logrus"
 )
 
-const storageDir = "blobs"
+const (
+	storageDir = "blobs"
+	stagingDir = ".staging"
+)
+
+type (
+	// fileBlobs stores node blobs as files under dir/<profile>/<node>,
+	// staging them under dir/.staging/<profile>/<node> until committed
+	fileBlobs struct {
+		dir string
+	}
+
+	// blobStage holds a profile's blobs aside until the profile commits
+	blobStage struct {
+		staged, target string
+		size           int64
+	}
+)
 
-func SaveBlob(profileId, id string, blob *storage.BlobStorage) error {
-	dir := path.Join(storageDir, profileId)
-	err := os.MkdirAll(dir, os.ModePerm)
+func newFileBlobs(dir string) (fileBlobs, error) {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return fileBlobs{}, err
+	}
+	return fileBlobs{dir: dir}, nil
+}
+
+// blobPath escapes ids so each is exactly one path element under dir,
+// leading dots are escaped too so no id names the staging directory
+func blobPath(dir string, ids ...string) (string, error) {
+	elems := []string{dir}
+	for _, id := range ids {
+		if id == "" {
+			return "", errors.New("empty blob id")
+		}
+		id = url.PathEscape(id)
+		if strings.HasPrefix(id, ".") {
+			id = "%2E" + id[1:]
+		}
+		elems = append(elems, id)
+	}
+	return path.Join(elems...), nil
+}
+
+// saveBlob writes blob and returns the number of bytes written
+func (fb fileBlobs) saveBlob(profileId, id string, blob *storage.BlobStorage) (int, error) {
+	dir, err := blobPath(fb.dir, profileId)
 	if err != nil {
-		return err
+		return 0, err
+	}
+	if err = os.MkdirAll(dir, os.ModePerm); err != nil {
+		return 0, err
+	}
+	fname, err := blobPath(dir, id)
+	if err != nil {
+		return 0, err
 	}
-	fname := path.Join(dir, id)
 	b, err := proto.Marshal(blob)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	if err := ioutil.WriteFile(fname, b, 0644); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// StageBlob writes blob aside until its profile is created
+func (fb fileBlobs) StageBlob(profileId, id string, blob *storage.BlobStorage) error {
+	staging := fileBlobs{dir: path.Join(fb.dir, stagingDir)}
+	_, err := staging.saveBlob(profileId, id, blob)
+	return err
+}
+
+func (fb fileBlobs) LoadBlob(profileId, id string) (*storage.BlobStorage, error) {
+	fname, err := blobPath(fb.dir, profileId, id)
+	if err != nil {
+		return nil, err
+	}
+	b, err := ioutil.ReadFile(fname)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("blob %s/%s %w", profileId, id, ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	blob := &storage.BlobStorage{}
+	if err := proto.Unmarshal(b, blob); err != nil {
+		return nil, err
+	}
+	return blob, nil
+}
+
+// profileSize counts the committed blobs of profileId and their bytes
+func (fb fileBlobs) profileSize(profileId string) (int, int64, error) {
+	dir, err := blobPath(fb.dir, profileId)
+	if err != nil {
+		return 0, 0, err
+	}
+	return dirSize(dir)
+}
+
+// deleteBlobs removes every blob of profileId including staged ones,
+// returning how many blobs and bytes were removed
+func (fb fileBlobs) deleteBlobs(profileId string) (int, int64, error) {
+	count, size, err := fb.profileSize(profileId)
+	if err != nil {
+		return 0, 0, err
+	}
+	dir, err := blobPath(fb.dir, profileId)
+	if err != nil {
+		return 0, 0, err
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return 0, 0, err
+	}
+	if err := fb.discardStaged(profileId); err != nil {
+		return 0, 0, err
+	}
+	return count, size, nil
+}
+
+// discardStaged removes blobs of profileId that were never committed
+func (fb fileBlobs) discardStaged(profileId string) error {
+	staged, err := blobPath(path.Join(fb.dir, stagingDir), profileId)
+	if err != nil {
 		return err
 	}
-	return nil
+	return os.RemoveAll(staged)
 }
 
-func AsyncSaveBlob(wg *sync.WaitGroup, errChan chan error, profileId, id string, blob *storage.BlobStorage) {
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		err := SaveBlob(profileId, id, blob)
-		if err != nil {
-			errChan <- fmt.Errorf("Save Job %s failed: %+v", id, err)
+// dirSize counts the files and their bytes in dir, a missing dir is empty
+func dirSize(dir string) (int, int64, error) {
+	var (
+		count int
+		size  int64
+	)
+	files, err := ioutil.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return 0, 0, err
+	}
+	for _, file := range files {
+		if !file.IsDir() {
+			count++
+			size += file.Size()
 		}
-	}()
+	}
+	return count, size, nil
 }
 
-func initBlob() {
-	err := os.MkdirAll(storageDir, os.ModePerm)
+// stageBlobs writes blobs of a profile's saved nodes into a staging directory
+// alongside any blobs already staged, discarding blobs of nodes not saved,
+// leaving nothing behind and reporting failed nodes if any write fails
+func (fb fileBlobs) stageBlobs(profileId string, blobs map[string]*storage.BlobStorage,
+	nodes []*TenncorNode) (*blobStage, error) {
+	staging := fileBlobs{dir: path.Join(fb.dir, stagingDir)}
+	staged, err := blobPath(staging.dir, profileId)
+	if err != nil {
+		return nil, err
+	}
+	target, err := blobPath(fb.dir, profileId)
 	if err != nil {
-		log.Error(err)
+		return nil, err
+	}
+	stage := &blobStage{staged: staged, target: target}
+	if err := os.MkdirAll(stage.staged, os.ModePerm); err != nil {
+		return nil, err
+	}
+	// saved nodes are named by their blob files
+	saved := make(map[string]struct{}, len(nodes))
+	for _, node := range nodes {
+		fname, err := blobPath(stage.staged, node.Id)
+		if err != nil {
+			stage.rollback()
+			return nil, err
+		}
+		saved[path.Base(fname)] = struct{}{}
+	}
+	errs := make(NodeErrors)
+	for id, blob := range blobs {
+		fname, err := blobPath(stage.staged, id)
+		if err != nil {
+			errs[id] = fmt.Errorf("saving blob: %v", err)
+			continue
+		}
+		if _, ok := saved[path.Base(fname)]; !ok {
+			continue
+		}
+		if _, err := staging.saveBlob(profileId, id, blob); err != nil {
+			errs[id] = fmt.Errorf("saving blob: %v", err)
+		}
+	}
+	if len(errs) > 0 {
+		stage.rollback()
+		return nil, errs
+	}
+	// blobs staged ahead may belong to nodes unreachable from the profile's roots
+	files, err := ioutil.ReadDir(stage.staged)
+	if err != nil {
+		stage.rollback()
+		return nil, err
+	}
+	for _, file := range files {
+		if _, ok := saved[file.Name()]; ok {
+			continue
+		}
+		if err := os.RemoveAll(path.Join(stage.staged, file.Name())); err != nil {
+			stage.rollback()
+			return nil, err
+		}
+	}
+	if _, stage.size, err = dirSize(stage.staged); err != nil {
+		stage.rollback()
+		return nil, err
+	}
+	return stage, nil
+}
+
+// commit moves staged blobs to where the profile's blobs are loaded from
+func (stage *blobStage) commit() error {
+	return os.Rename(stage.staged, stage.target)
+}
+
+// uncommit moves committed blobs back into staging
+func (stage *blobStage) uncommit() error {
+	return os.Rename(stage.target, stage.staged)
+}
+
+// rollback removes staged blobs as well as any committed ones
+func (stage *blobStage) rollback() {
+	for _, dir := range []string{stage.staged, stage.target} {
+		if err := os.RemoveAll(dir); err != nil {
+			log.Errorf("Failed to remove blobs %s: %v", dir, err)
+		}
 	}
 }
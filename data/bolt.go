@@ -0,0 +1,342 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/mingkaic/accretion/proto/storage"
+)
+
+type (
+	// boltStore is an embedded store persisting profiles to a bbolt file,
+	// so accretion can run without a Dgraph server
+	boltStore struct {
+		fileBlobs
+		db *bolt.DB
+	}
+
+	// boltNode is the record stored per node, args and annotations
+	// are flattened to ids
+	boltNode struct {
+		*TenncorNode
+		ArgIds  []string `json:"arg_ids,omitempty"`
+		AttrIds []string `json:"attr_ids,omitempty"`
+	}
+)
+
+const (
+	boltFile = "accretion.db"
+)
+
+var (
+	profilesBucket    = []byte("profiles")
+	recordsBucket     = []byte("records")
+	annotationsBucket = []byte("annotations")
+)
+
+func NewBoltStore(dir string) (Store, error) {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, err
+	}
+	db, err := bolt.Open(path.Join(dir, boltFile), 0600,
+		&bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+	blobs, err := newFileBlobs(path.Join(dir, storageDir))
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	if err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{profilesBucket, recordsBucket, annotationsBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return backfillBoltRecords(tx, blobs)
+	}); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &boltStore{fileBlobs: blobs, db: db}, nil
+}
+
+// backfillBoltRecords creates records for profiles ingested before profiles had records,
+// so they are listed and collected like any other
+func backfillBoltRecords(tx *bolt.Tx, blobs fileBlobs) error {
+	records := tx.Bucket(recordsBucket)
+	annotations := tx.Bucket(annotationsBucket)
+	return tx.Bucket(profilesBucket).ForEach(func(k, v []byte) error {
+		// profiles are buckets of nodes, so have no value
+		if v != nil || records.Get(k) != nil {
+			return nil
+		}
+		profileId := string(k)
+		var nodes []*TenncorNode
+		if err := tx.Bucket(profilesBucket).Bucket(k).ForEach(func(_, v []byte) error {
+			node, err := unmarshalBoltNode(v, annotations)
+			if err != nil {
+				return err
+			}
+			nodes = append(nodes, node)
+			return nil
+		}); err != nil {
+			return err
+		}
+		_, blobBytes, err := blobs.profileSize(profileId)
+		if err != nil {
+			return err
+		}
+		if err = putBoltRecord(tx, legacyRecord(profileId, nodes, blobBytes)); err != nil {
+			return err
+		}
+		log.Infof("Backfilled the record of profile %s", profileId)
+		return nil
+	})
+}
+
+func (store *boltStore) CreateProfile(profile *TenncorProfile, roots []*TenncorNode,
+	blobs map[string]*storage.BlobStorage) error {
+	profileId := profile.ProfileId
+	nodes := FlattenNodes(roots)
+	stage, err := store.stageBlobs(profileId, blobs, nodes)
+	if err != nil {
+		return err
+	}
+	profile.BlobBytes = stage.size
+	profile.NodeCount = len(nodes)
+	if err = store.db.Update(func(tx *bolt.Tx) error {
+		profiles := tx.Bucket(profilesBucket)
+		if profiles.Bucket([]byte(profileId)) != nil {
+			return fmt.Errorf("profile %s already exists", profileId)
+		}
+		bucket, err := profiles.CreateBucket([]byte(profileId))
+		if err != nil {
+			return err
+		}
+		annotations := tx.Bucket(annotationsBucket)
+		for _, node := range nodes {
+			for _, annotation := range node.Annotations {
+				if annotations.Get([]byte(annotation.Id)) != nil {
+					continue
+				}
+				stored := *annotation
+				stored.Uid = ""
+				b, err := json.Marshal(stored)
+				if err != nil {
+					return err
+				}
+				if err = annotations.Put([]byte(annotation.Id), b); err != nil {
+					return err
+				}
+			}
+			b, err := marshalBoltNode(node)
+			if err != nil {
+				return err
+			}
+			if err = bucket.Put([]byte(node.Id), b); err != nil {
+				return err
+			}
+		}
+		if err = putBoltRecord(tx, profile); err != nil {
+			return err
+		}
+		return stage.commit()
+	}); err != nil {
+		stage.rollback()
+		return err
+	}
+	return nil
+}
+
+func (store *boltStore) FailProfile(failed *TenncorProfile, cause error) error {
+	if err := store.discardStaged(failed.ProfileId); err != nil {
+		return err
+	}
+	return store.db.Update(func(tx *bolt.Tx) error {
+		profile, err := getBoltRecord(tx, failed.ProfileId)
+		if errors.Is(err, ErrNotFound) {
+			profile = failed
+		} else if err != nil {
+			return err
+		}
+		profile.Status = ProfileFailed
+		profile.Error = cause.Error()
+		return putBoltRecord(tx, profile)
+	})
+}
+
+func (store *boltStore) GetProfile(profileId string) (*TenncorProfile, error) {
+	var profile *TenncorProfile
+	if err := store.db.View(func(tx *bolt.Tx) (err error) {
+		profile, err = getBoltRecord(tx, profileId)
+		return
+	}); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+func (store *boltStore) ListProfileRecords(query *ProfileQuery) ([]*TenncorProfile, error) {
+	var profiles []*TenncorProfile
+	if err := store.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(recordsBucket).ForEach(func(k, v []byte) error {
+			profile := &TenncorProfile{}
+			if err := json.Unmarshal(v, profile); err != nil {
+				return err
+			}
+			profiles = append(profiles, profile)
+			return nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+	return query.apply(profiles), nil
+}
+
+func (store *boltStore) SetProfilePinned(profileId string, pinned bool) error {
+	return store.db.Update(func(tx *bolt.Tx) error {
+		profile, err := getBoltRecord(tx, profileId)
+		if err != nil {
+			return err
+		}
+		profile.Pinned = pinned
+		return putBoltRecord(tx, profile)
+	})
+}
+
+func (store *boltStore) GetProfileNodes(profileId string) ([]*TenncorNode, error) {
+	var nodes []*TenncorNode
+	if err := store.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(profilesBucket).Bucket([]byte(profileId))
+		if bucket == nil {
+			return nil
+		}
+		annotations := tx.Bucket(annotationsBucket)
+		return bucket.ForEach(func(k, v []byte) error {
+			node, err := unmarshalBoltNode(v, annotations)
+			if err != nil {
+				return err
+			}
+			nodes = append(nodes, node)
+			return nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+func (store *boltStore) DeleteProfile(profileId string) (*DeletedProfile, error) {
+	deleted := &DeletedProfile{}
+	if err := store.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(recordsBucket).Delete([]byte(profileId)); err != nil {
+			return err
+		}
+		profiles := tx.Bucket(profilesBucket)
+		bucket := profiles.Bucket([]byte(profileId))
+		if bucket == nil {
+			return nil
+		}
+		if err := bucket.ForEach(func(k, v []byte) error {
+			record := boltNode{TenncorNode: &TenncorNode{}}
+			if err := json.Unmarshal(v, &record); err != nil {
+				return err
+			}
+			deleted.Nodes++
+			deleted.AnnotationEdges += len(record.AttrIds)
+			return nil
+		}); err != nil {
+			return err
+		}
+		return profiles.DeleteBucket([]byte(profileId))
+	}); err != nil {
+		return nil, err
+	}
+	var err error
+	if deleted.Blobs, deleted.BlobBytes, err = store.deleteBlobs(profileId); err != nil {
+		return nil, err
+	}
+	return deleted, nil
+}
+
+// Ready is always nil since the database is opened with the store
+func (store *boltStore) Ready(ctx context.Context) error {
+	return nil
+}
+
+func (store *boltStore) Close() error {
+	return store.db.Close()
+}
+
+func getBoltRecord(tx *bolt.Tx, profileId string) (*TenncorProfile, error) {
+	b := tx.Bucket(recordsBucket).Get([]byte(profileId))
+	if b == nil {
+		return nil, fmt.Errorf("profile %s %w", profileId, ErrNotFound)
+	}
+	profile := &TenncorProfile{}
+	if err := json.Unmarshal(b, profile); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+func putBoltRecord(tx *bolt.Tx, profile *TenncorProfile) error {
+	stored := *profile
+	stored.Uid = ""
+	b, err := json.Marshal(stored)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(recordsBucket).Put([]byte(profile.ProfileId), b)
+}
+
+func marshalBoltNode(node *TenncorNode) ([]byte, error) {
+	flat := *node
+	flat.Args = nil
+	flat.Annotations = nil
+	argIds := make([]string, 0, len(node.Args))
+	for _, arg := range node.Args {
+		if arg != nil {
+			argIds = append(argIds, arg.Id)
+		}
+	}
+	attrIds := make([]string, len(node.Annotations))
+	for i, annotation := range node.Annotations {
+		attrIds[i] = annotation.Id
+	}
+	return json.Marshal(boltNode{TenncorNode: &flat, ArgIds: argIds, AttrIds: attrIds})
+}
+
+func unmarshalBoltNode(b []byte, annotations *bolt.Bucket) (*TenncorNode, error) {
+	record := boltNode{TenncorNode: &TenncorNode{}}
+	if err := json.Unmarshal(b, &record); err != nil {
+		return nil, err
+	}
+	node := record.TenncorNode
+	node.Args = make([]*TenncorNode, len(record.ArgIds))
+	for i, argId := range record.ArgIds {
+		node.Args[i] = &TenncorNode{Id: argId}
+	}
+	for _, attrId := range record.AttrIds {
+		ab := annotations.Get([]byte(attrId))
+		if ab == nil {
+			return nil, fmt.Errorf("node %s has missing annotation %s", node.Id, attrId)
+		}
+		annotation := &Annotation{}
+		if err := json.Unmarshal(ab, annotation); err != nil {
+			return nil, err
+		}
+		node.Annotations = append(node.Annotations, annotation)
+	}
+	return node, nil
+}
@@ -0,0 +1,201 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/dgo/v200"
+	"github.com/dgraph-io/dgo/v200/protos/api"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+
+	"github.com/mingkaic/accretion/config"
+)
+
+const (
+	minBackoff   = 500 * time.Millisecond
+	maxBackoff   = 30 * time.Second
+	setupTimeout = 30 * time.Second
+
+	// once set up, dgraph is probed this often to notice outages
+	probeInterval = 5 * time.Second
+	probeTimeout  = 2 * time.Second
+	probeQuery    = `{ probe(func: uid(0x1)) { uid } }`
+)
+
+type (
+	// dgraphConn keeps one pooled dgraph client, logging in and publishing
+	// the schema in the background until dgraph is reachable,
+	// then probing dgraph for as long as the connection is open
+	dgraphConn struct {
+		cfg    config.Dgraph
+		schema string
+		conn   *grpc.ClientConn
+		dg     *dgo.Dgraph
+		done   chan struct{}
+		// migrate runs once the schema is published, before the connection is ready
+		migrate func(*dgo.Dgraph) error
+
+		mu sync.RWMutex
+		// err is why the connection isn't ready, nil while ready
+		err error
+		// setUp is whether the schema was published, after which dgraph can be probed
+		setUp bool
+	}
+)
+
+func newDgraphConn(cfg config.Dgraph, migrate func(*dgo.Dgraph) error) (*dgraphConn, error) {
+	// the schema is read up front so a bad file fails startup rather than every retry
+	b, err := ioutil.ReadFile(cfg.SchemaFile)
+	if err != nil {
+		return nil, fmt.Errorf("dgraph schema: %v", err)
+	}
+	schema := string(b)
+	if strings.TrimSpace(schema) == "" {
+		return nil, fmt.Errorf("dgraph schema %s is empty", cfg.SchemaFile)
+	}
+	// dialing doesn't block, so an unreachable dgraph only delays readiness
+	conn, err := grpc.Dial(cfg.Url, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	c := &dgraphConn{
+		cfg:     cfg,
+		schema:  schema,
+		conn:    conn,
+		dg:      dgo.NewDgraphClient(api.NewDgraphClient(conn)),
+		done:    make(chan struct{}),
+		migrate: migrate,
+		err:     errors.New("connecting"),
+	}
+	go c.connect()
+	return c, nil
+}
+
+// connect retries setup with exponential backoff until it succeeds,
+// then monitors dgraph until c closes
+func (c *dgraphConn) connect() {
+	backoff := minBackoff
+	for {
+		err := c.setup()
+		c.mu.Lock()
+		c.err = err
+		c.setUp = err == nil
+		c.mu.Unlock()
+		if err == nil {
+			log.Infof("Connected to dgraph at %s", c.cfg.Url)
+			c.monitor()
+			return
+		}
+		log.Warnf("Dgraph at %s is not ready, retrying in %s: %v", c.cfg.Url, backoff, err)
+		select {
+		case <-c.done:
+			return
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+func (c *dgraphConn) setup() error {
+	ctx, cancel := context.WithTimeout(context.Background(), setupTimeout)
+	defer cancel()
+	if c.cfg.EnableAcl {
+		// Perform login call. If the Dgraph cluster does not have ACL and
+		// enterprise features enabled, this call should be skipped.
+		if err := c.dg.Login(ctx, c.cfg.User, c.cfg.Password); err != nil {
+			return fmt.Errorf("login: %v", err)
+		}
+	}
+
+	log.Debug("Publishing schema")
+	op := &api.Operation{}
+	op.Schema = c.schema
+	if err := c.dg.Alter(ctx, op); err != nil {
+		return err
+	}
+	if c.migrate == nil {
+		return nil
+	}
+	if err := c.migrate(c.dg); err != nil {
+		return fmt.Errorf("migrate: %v", err)
+	}
+	return nil
+}
+
+// monitor probes dgraph every probeInterval until c closes,
+// so outages after setup make the connection not ready until dgraph recovers
+func (c *dgraphConn) monitor() {
+	ticker := time.NewTicker(probeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+		c.probe(ctx)
+		cancel()
+	}
+}
+
+// probe runs a trivial read-only query, recording whether dgraph is ready
+func (c *dgraphConn) probe(ctx context.Context) error {
+	_, err := c.dg.NewReadOnlyTxn().BestEffort().Query(ctx, probeQuery)
+	c.mu.Lock()
+	last := c.err
+	c.err = err
+	c.mu.Unlock()
+	if (err == nil) != (last == nil) {
+		if err == nil {
+			log.Infof("Dgraph at %s recovered", c.cfg.Url)
+		} else {
+			log.Warnf("Dgraph at %s is not ready: %v", c.cfg.Url, err)
+		}
+	}
+	return err
+}
+
+// check probes dgraph now once the schema is published, otherwise is why it isn't
+func (c *dgraphConn) check(ctx context.Context) error {
+	c.mu.RLock()
+	setUp := c.setUp
+	c.mu.RUnlock()
+	if !setUp {
+		return c.ready()
+	}
+	if err := c.probe(ctx); err != nil {
+		return fmt.Errorf("dgraph %w: %v", ErrNotReady, err)
+	}
+	return nil
+}
+
+// ready is nil while dgraph was last reachable, otherwise why it isn't
+func (c *dgraphConn) ready() error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.err != nil {
+		return fmt.Errorf("dgraph %w: %v", ErrNotReady, c.err)
+	}
+	return nil
+}
+
+func (c *dgraphConn) client() (*dgo.Dgraph, error) {
+	if err := c.ready(); err != nil {
+		return nil, err
+	}
+	return c.dg, nil
+}
+
+func (c *dgraphConn) close() error {
+	close(c.done)
+	return c.conn.Close()
+}
@@ -2,49 +2,286 @@ package data
 
 import (
 	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// InferenceSection is the section of nodes in the model's main graph
+	InferenceSection = "inference"
+
+	ProfileComplete = "complete"
+	ProfileFailed   = "failed"
+
+	PlaceholderKind    = "placeholder"
+	VariableKind       = "variable"
+	SparseVariableKind = "sparse_variable"
+	FunctionKind       = "function"
 )
 
 type (
+	// TenncorProfile records a profile's ingestion along with
+	// the metadata of its model
+	TenncorProfile struct {
+		Uid             string     `json:"uid,omitempty"`
+		DgraphType      string     `json:"dgraph.type,omitempty"`
+		ProfileId       string     `json:"profile_id"`
+		Name            string     `json:"name,omitempty"`
+		Tags            []string   `json:"tags,omitempty"`
+		Status          string     `json:"status"`
+		Error           string     `json:"status_error,omitempty"`
+		CreatedAt       time.Time  `json:"created_at"`
+		Model           string     `json:"model"`
+		ProducerName    string     `json:"producer_name,omitempty"`
+		ProducerVersion string     `json:"producer_version,omitempty"`
+		ModelVersion    int64      `json:"model_version,omitempty"`
+		ModelDomain     string     `json:"model_domain,omitempty"`
+		DocString       string     `json:"doc_string,omitempty"`
+		MetadataProps   Properties `json:"metadata_props,omitempty"`
+		OpsetImport     Properties `json:"opset_import,omitempty"`
+		TotalRuntime    uint64     `json:"total_runtime"`
+		NodeCount       int        `json:"node_count"`
+		BlobBytes       int64      `json:"blob_bytes"`
+		Pinned          bool       `json:"pinned"`
+	}
+
+	// TenncorNode is an operator or tensor of a profile, nodes in control-flow
+	// subgraphs have the owning node as Parent and the subgraph attribute as Scope,
+	// nodes of training graphs are in a Section, and initializers trained by them
+	// are InitializedBy and UpdatedBy values of those graphs
 	TenncorNode struct {
-		Uid         string         `json:"uid"`
-		ProfileId   string         `json:"profile_id"`
-		Id          string         `json:"id"`
-		Label       string         `json:"label"`
-		Shape       []uint64       `json:"shape"`
-		Runtime     uint64         `json:"runtime,omitempty"`
-		Args        []*TenncorNode `json:"arg,omitempty"`
-		Annotations []*Annotation  `json:"-"`
-		Data        []float64      `json:"-"`
-		Sinfo       *SparseInfo    `json:"-"`
-		ArgIds      []string       `json:"-"`
+		Uid           string         `json:"uid"`
+		ProfileId     string         `json:"profile_id"`
+		Id            string         `json:"id"`
+		Label         string         `json:"label"`
+		Kind          string         `json:"kind,omitempty"`
+		Domain        string         `json:"domain,omitempty"`
+		Shape         Shape          `json:"dims,omitempty"`
+		Dtype         string         `json:"dtype,omitempty"`
+		Runtime       uint64         `json:"runtime,omitempty"`
+		Args          []*TenncorNode `json:"arg,omitempty"`
+		Inputs        Values         `json:"input,omitempty"`
+		Outputs       Values         `json:"output,omitempty"`
+		OutputTypes   ValueTypes     `json:"output_type,omitempty"`
+		Parent        string         `json:"parent,omitempty"`
+		Scope         string         `json:"scope,omitempty"`
+		Section       string         `json:"section,omitempty"`
+		InitializedBy Values         `json:"initialized_by,omitempty"`
+		UpdatedBy     Values         `json:"updated_by,omitempty"`
+		Annotations   []*Annotation  `json:"attr,omitempty"`
+		Raw           []byte         `json:"-"`
+		Strings       [][]byte       `json:"-"`
+		Sinfo         *SparseInfo    `json:"-"`
 	}
 
+	// Annotation is a key-value attribute shared by every node
+	// with the same key and value, Id is the hash of both
 	Annotation struct {
-		Uid   string `json:"uid"`
-		Id    string `json:"-"`
+		Uid   string `json:"uid,omitempty"`
+		Id    string `json:"hash"`
 		Key   string `json:"key"`
 		Value string `json:"val"`
 	}
 
+	// Shape is stored as a comma separated string,
+	// since dgraph lists are unordered and deduplicated
+	Shape []uint64
+
+	// Properties is stored as a json object string, since dgraph has no maps
+	Properties map[string]string
+
+	// Values are names of the tensors flowing between nodes in order,
+	// stored as a json array string since names may repeat
+	Values []string
+
+	// ValueType is the declared type of a value, dims are static sizes,
+	// symbolic parameters or ? when unknown
+	ValueType struct {
+		Name  string   `json:"name"`
+		Dtype string   `json:"dtype,omitempty"`
+		Dims  []string `json:"dims,omitempty"`
+	}
+
+	// ValueTypes is stored as a json array string
+	ValueTypes []*ValueType
+
 	SparseInfo struct {
 		Indices      []int32 `json:"-"`
 		OuterIndices []int64 `json:"-"`
 	}
 )
 
+func NewTenncorProfile(profileId string) *TenncorProfile {
+	return &TenncorProfile{
+		Uid:        fmt.Sprintf("_:%s", profileId),
+		DgraphType: "TenncorProfile",
+		ProfileId:  profileId,
+		Status:     ProfileComplete,
+		CreatedAt:  time.Now().UTC(),
+	}
+}
+
+// legacyRecord is the record backfilled for a profile ingested before
+// profiles had records, created when it is backfilled since that is unknown
+func legacyRecord(profileId string, nodes []*TenncorNode, blobBytes int64) *TenncorProfile {
+	profile := NewTenncorProfile(profileId)
+	profile.NodeCount = len(nodes)
+	profile.BlobBytes = blobBytes
+	for _, node := range nodes {
+		profile.TotalRuntime += node.Runtime
+	}
+	return profile
+}
+
 func NewAnnotation(key, val string) *Annotation {
 	kh := sha1.Sum([]byte(key))
 	vh := sha1.Sum([]byte(val))
-	id := sha1.Sum(append(kh[:], vh[:]...))
+	hash := sha1.Sum(append(kh[:], vh[:]...))
+	id := hex.EncodeToString(hash[:])
 	return &Annotation{
-		Uid:   fmt.Sprintf("_:%s", id[:]),
-		Id:    string(id[:]),
+		Uid:   fmt.Sprintf("_:%s", id),
+		Id:    id,
 		Key:   key,
 		Value: val,
 	}
 }
 
+func (s Shape) MarshalJSON() ([]byte, error) {
+	dims := make([]string, len(s))
+	for i, d := range s {
+		dims[i] = strconv.FormatUint(d, 10)
+	}
+	return json.Marshal(strings.Join(dims, ","))
+}
+
+func (s *Shape) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		return err
+	}
+	*s = nil
+	if str == "" {
+		return nil
+	}
+	dims := strings.Split(str, ",")
+	shape := make(Shape, len(dims))
+	for i, dim := range dims {
+		d, err := strconv.ParseUint(dim, 10, 64)
+		if err != nil {
+			return fmt.Errorf("bad shape %s: %v", str, err)
+		}
+		shape[i] = d
+	}
+	*s = shape
+	return nil
+}
+
+func (p Properties) MarshalJSON() ([]byte, error) {
+	b, err := json.Marshal(map[string]string(p))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(b))
+}
+
+func (p *Properties) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		return err
+	}
+	*p = nil
+	if str == "" {
+		return nil
+	}
+	props := make(map[string]string)
+	if err := json.Unmarshal([]byte(str), &props); err != nil {
+		return fmt.Errorf("bad properties %s: %v", str, err)
+	}
+	*p = props
+	return nil
+}
+
+func (v Values) MarshalJSON() ([]byte, error) {
+	b, err := json.Marshal([]string(v))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(b))
+}
+
+func (v *Values) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		return err
+	}
+	*v = nil
+	if str == "" {
+		return nil
+	}
+	var values []string
+	if err := json.Unmarshal([]byte(str), &values); err != nil {
+		return fmt.Errorf("bad values %s: %v", str, err)
+	}
+	*v = values
+	return nil
+}
+
+func (t ValueTypes) MarshalJSON() ([]byte, error) {
+	b, err := json.Marshal([]*ValueType(t))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(b))
+}
+
+func (t *ValueTypes) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		return err
+	}
+	*t = nil
+	if str == "" {
+		return nil
+	}
+	var types []*ValueType
+	if err := json.Unmarshal([]byte(str), &types); err != nil {
+		return fmt.Errorf("bad value types %s: %v", str, err)
+	}
+	*t = types
+	return nil
+}
+
+// FlattenNodes lists every node reachable from roots exactly once,
+// args are listed before the nodes consuming them
+func FlattenNodes(roots []*TenncorNode) []*TenncorNode {
+	var (
+		nodes   []*TenncorNode
+		visited = make(map[string]struct{})
+		visit   func(*TenncorNode)
+	)
+	visit = func(node *TenncorNode) {
+		if node == nil {
+			return
+		}
+		if _, ok := visited[node.Id]; ok {
+			return
+		}
+		visited[node.Id] = struct{}{}
+		for _, arg := range node.Args {
+			visit(arg)
+		}
+		nodes = append(nodes, node)
+	}
+	for _, root := range roots {
+		visit(root)
+	}
+	return nodes
+}
+
 func (tn *TenncorNode) ToString() string {
 	return tn.Id
 }
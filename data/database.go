@@ -1,53 +1,13 @@
 package data
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
-	"strings"
-	"sync"
-	"time"
 
-	"github.com/dgraph-io/dgo/v200"
 	"github.com/dgraph-io/dgo/v200/protos/api"
 	log "github.com/sirupsen/logrus"
-	"google.golang.org/grpc"
 )
 
-var (
-	doOnce sync.Once
-)
-
-const (
-	dbUrl        = "127.0.0.1:9080"
-	dbUser       = "groot"
-	dbPwd        = "password"
-	dbSchemaFile = "data/schema.dql"
-	enabledAcl   = false
-)
-
-func init() {
-	doOnce.Do(func() {
-		dg, cancel := getDgraphClient()
-		defer cancel()
-
-		log.Info("Publishing schema")
-		b, err := ioutil.ReadFile(dbSchemaFile)
-		if err != nil {
-			log.Fatal(err)
-		}
-		op := &api.Operation{}
-		op.Schema = string(b)
-		ctx := context.Background()
-		if err := dg.Alter(ctx, op); err != nil {
-			log.Fatal(err)
-		}
-
-		initBlob()
-	})
-}
-
 func QueryNode(tx *Txn, q string) ([]byte, error) {
 	res, err := tx.Query(q)
 	if err != nil {
@@ -56,74 +16,54 @@ func QueryNode(tx *Txn, q string) ([]byte, error) {
 	return res.GetJson(), nil
 }
 
-func CreateNode(tx *Txn, node interface{}) error {
+// QueryNodeWithVars queries with vars bound to the query's $ parameters,
+// so request values never splice into the query itself
+func QueryNodeWithVars(tx *Txn, q string, vars map[string]string) ([]byte, error) {
+	res, err := tx.QueryWithVars(q, vars)
+	if err != nil {
+		return nil, err
+	}
+	return res.GetJson(), nil
+}
+
+func CreateNode(tx *Txn, node interface{}) (map[string]string, error) {
 	mu := &api.Mutation{}
 	pb, err := json.Marshal(node)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	mu.SetJson = pb
-	_, err = tx.Mutate(mu)
+	res, err := tx.Mutate(mu)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return nil
+	return res.GetUids(), nil
 }
 
-func AsyncCreateNode(wg *sync.WaitGroup, errChan chan error, id string, tx *Txn, node interface{}) {
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		err := CreateNode(tx, node)
-		if err != nil {
-			errChan <- fmt.Errorf("Job %s failed: %+v", id, err)
-		}
-	}()
-}
-
-func BatchCreateNodes(wg *sync.WaitGroup, errChan chan error, tx *Txn, nodes []interface{}, batchsize int) {
+// BatchCreateNodes creates nodes in batches within tx, nodes must be
+// ordered such that args precede the nodes consuming them
+func BatchCreateNodes(tx *Txn, nodes []*TenncorNode, batchsize int) error {
 	nnodes := len(nodes)
-	nbatches := nnodes / batchsize
-	log.Debugf("saving nodes %d by %d batches", nnodes, nbatches)
-	for i := 0; i < nbatches; i++ {
-		startIdx := i * batchsize
-		AsyncCreateNode(wg, errChan, fmt.Sprintf("%d", i), tx, nodes[startIdx:startIdx+batchsize])
-	}
-	if nbatches*batchsize < nnodes {
-		log.Debug("saving remainder batch")
-		AsyncCreateNode(wg, errChan, fmt.Sprintf("%d", nbatches+1), tx, nodes[nbatches*batchsize:])
-	}
-}
-
-func getDgraphClient() (*dgo.Dgraph, func()) {
-	conn, err := grpc.Dial(dbUrl, grpc.WithInsecure())
-	if err != nil {
-		log.Fatal("While trying to dial gRPC")
-	}
-
-	dc := api.NewDgraphClient(conn)
-	dg := dgo.NewDgraphClient(dc)
-
-	if enabledAcl {
-		// Perform login call. If the Dgraph cluster does not have ACL and
-		// enterprise features enabled, this call should be skipped.
-		ctx := context.Background()
-		for {
-			// Keep retrying until we succeed or receive a non-retriable error.
-			err = dg.Login(ctx, dbUser, dbPwd)
-			if err == nil || !strings.Contains(err.Error(), "Please retry") {
-				break
-			}
-			time.Sleep(time.Second)
+	log.Debugf("saving nodes %d by batches of %d", nnodes, batchsize)
+	// blank node names are scoped to a mutation,
+	// so later batches refer to earlier nodes by their assigned uids
+	uids := make(map[string]string)
+	for startIdx := 0; startIdx < nnodes; startIdx += batchsize {
+		endIdx := startIdx + batchsize
+		if endIdx > nnodes {
+			endIdx = nnodes
 		}
+		batch := make([]*dgraphNode, 0, endIdx-startIdx)
+		for _, node := range nodes[startIdx:endIdx] {
+			batch = append(batch, newDgraphNode(node, uids))
+		}
+		created, err := CreateNode(tx, batch)
 		if err != nil {
-			log.Fatalf("While trying to login %v", err.Error())
+			return fmt.Errorf("Job %d failed: %+v", startIdx/batchsize, err)
 		}
-	}
-
-	return dg, func() {
-		if err := conn.Close(); err != nil {
-			log.Infof("Error while closing connection:%v", err)
+		for blank, uid := range created {
+			uids[blank] = uid
 		}
 	}
+	return nil
 }
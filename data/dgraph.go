@@ -0,0 +1,576 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/dgraph-io/dgo/v200"
+	"github.com/dgraph-io/dgo/v200/protos/api"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/mingkaic/accretion/config"
+	"github.com/mingkaic/accretion/proto/storage"
+)
+
+type (
+	dgraphStore struct {
+		fileBlobs
+		conn *dgraphConn
+	}
+
+	uidEntry struct {
+		Uid string `json:"uid"`
+	}
+
+	deletionEntry struct {
+		Uid         string `json:"uid"`
+		Annotations int    `json:"attrs"`
+	}
+
+	// dgraphNode is a node mutation referring to its args by uid
+	dgraphNode struct {
+		*TenncorNode
+		Args []*uidEntry `json:"arg,omitempty"`
+	}
+)
+
+const (
+	batchsize = 8
+	// creating a profile is retried this many times when aborted by a conflict
+	maxCreateAttempts = 3
+	// regexp filters need trigrams from at least this many characters
+	minTrigramLength = 3
+
+	profileRecordFields = `
+		uid
+		profile_id
+		name
+		tags
+		status
+		status_error
+		created_at
+		model
+		producer_name
+		producer_version
+		model_version
+		model_domain
+		doc_string
+		metadata_props
+		opset_import
+		total_runtime
+		node_count
+		blob_bytes
+		pinned`
+	profileRecordLookup = `query profile($id: string) {
+	profiles(func: eq(profile_id, $id)) @filter(type(TenncorProfile)) {` +
+		profileRecordFields + `
+	}
+}`
+	profileRecordsLookupFmt = `{
+	profiles(func: type(TenncorProfile)%s)%s {` +
+		profileRecordFields + `
+	}
+}`
+	nodesLookup = `query nodes($id: string) {
+	nodes(func: allofterms(profile_id, $id)) @filter(NOT type(TenncorProfile)) {
+		id
+		label
+		kind
+		domain
+		dims
+		dtype
+		runtime
+		input
+		output
+		output_type
+		parent
+		scope
+		section
+		initialized_by
+		updated_by
+		arg {
+			id
+		}
+		attr {
+			hash
+			key
+			val
+		}
+	}
+}`
+	annotationsLookupFmt = `{
+	annotations(func: eq(hash, %s)) {
+		uid
+		hash
+	}
+}`
+	// records are counted by node groups since profiles once had none
+	legacyProfilesLookup = `{
+	nodes(func: has(profile_id)) @filter(NOT type(TenncorProfile)) @groupby(profile_id) {
+		count(uid)
+	}
+	profiles(func: type(TenncorProfile)) {
+		profile_id
+	}
+}`
+	legacyNodesLookup = `query nodes($id: string) {
+	nodes(func: eq(profile_id, $id)) @filter(NOT type(TenncorProfile)) {
+		runtime
+	}
+}`
+	deletionsLookup = `query deletions($id: string) {
+	nodes(func: eq(profile_id, $id)) @filter(NOT type(TenncorProfile)) {
+		uid
+		attrs: count(attr)
+	}
+	profiles(func: eq(profile_id, $id)) @filter(type(TenncorProfile)) {
+		uid
+	}
+}`
+)
+
+var (
+	// deleting by uid alone only drops predicates of the node's dgraph.type,
+	// so deletions list every predicate instead
+	nodePredicates = []string{"dgraph.type", "profile_id", "id", "label",
+		"kind", "domain", "dims", "dtype", "runtime", "input", "output",
+		"output_type", "parent", "scope", "section", "initialized_by", "updated_by",
+		"arg", "attr"}
+	profilePredicates = []string{"dgraph.type", "profile_id", "name", "tags",
+		"status", "status_error", "created_at", "model", "producer_name",
+		"producer_version", "model_version", "model_domain", "doc_string",
+		"metadata_props", "opset_import", "total_runtime", "node_count",
+		"blob_bytes", "pinned"}
+)
+
+// NewDgraphStore connects to dgraph in the background,
+// the store is not ready until the schema is published
+func NewDgraphStore(cfg config.Dgraph) (Store, error) {
+	blobs, err := newFileBlobs(cfg.BlobDir)
+	if err != nil {
+		return nil, err
+	}
+	store := &dgraphStore{fileBlobs: blobs}
+	if store.conn, err = newDgraphConn(cfg, store.backfillRecords); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// backfillRecords creates records for profiles ingested before profiles had records,
+// so they are listed and collected like any other
+func (store *dgraphStore) backfillRecords(dg *dgo.Dgraph) error {
+	return WithTx(dg, func(tx *Txn) error {
+		b, err := QueryNode(tx, legacyProfilesLookup)
+		if err != nil {
+			return err
+		}
+		var response struct {
+			Nodes []struct {
+				Groups []struct {
+					ProfileId string `json:"profile_id"`
+				} `json:"@groupby"`
+			} `json:"nodes"`
+			Profiles []*TenncorProfile `json:"profiles"`
+		}
+		if err = json.Unmarshal(b, &response); err != nil {
+			return err
+		}
+		recorded := make(map[string]struct{}, len(response.Profiles))
+		for _, profile := range response.Profiles {
+			recorded[profile.ProfileId] = struct{}{}
+		}
+		for _, nodes := range response.Nodes {
+			for _, group := range nodes.Groups {
+				if _, ok := recorded[group.ProfileId]; ok {
+					continue
+				}
+				if err = backfillRecord(tx, store.fileBlobs, group.ProfileId); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+func backfillRecord(tx *Txn, blobs fileBlobs, profileId string) error {
+	b, err := QueryNodeWithVars(tx, legacyNodesLookup, map[string]string{"$id": profileId})
+	if err != nil {
+		return err
+	}
+	response := make(map[string][]*TenncorNode)
+	if err = json.Unmarshal(b, &response); err != nil {
+		return err
+	}
+	_, blobBytes, err := blobs.profileSize(profileId)
+	if err != nil {
+		return err
+	}
+	profile := legacyRecord(profileId, response["nodes"], blobBytes)
+	if _, err = CreateNode(tx, profile); err != nil {
+		return err
+	}
+	log.Infof("Backfilled the record of profile %s", profileId)
+	return nil
+}
+
+func (store *dgraphStore) Ready(ctx context.Context) error {
+	return store.conn.check(ctx)
+}
+
+func (store *dgraphStore) Close() error {
+	return store.conn.close()
+}
+
+func (store *dgraphStore) withTx(txFn func(*Txn) error) error {
+	dg, err := store.conn.client()
+	if err != nil {
+		return err
+	}
+	return WithTx(dg, txFn)
+}
+
+func (store *dgraphStore) CreateProfile(profile *TenncorProfile, roots []*TenncorNode,
+	blobs map[string]*storage.BlobStorage) error {
+	nodes := FlattenNodes(roots)
+	stage, err := store.stageBlobs(profile.ProfileId, blobs, nodes)
+	if err != nil {
+		return err
+	}
+	profile.BlobBytes = stage.size
+	profile.NodeCount = len(nodes)
+	for attempt := 1; ; attempt++ {
+		moved := false
+		err = store.withTx(func(tx *Txn) error {
+			if err := linkAnnotations(tx, roots); err != nil {
+				return err
+			}
+			if err := BatchCreateNodes(tx, nodes, batchsize); err != nil {
+				return err
+			}
+			if _, err := CreateNode(tx, profile); err != nil {
+				return err
+			}
+			// blobs move in last so only the commit itself can fail after
+			if err := stage.commit(); err != nil {
+				return err
+			}
+			moved = true
+			return nil
+		})
+		// profiles creating the same annotations at once conflict on their upserted hash,
+		// the aborted profile links to the annotations committed first when retried
+		if !errors.Is(err, dgo.ErrAborted) || attempt == maxCreateAttempts {
+			break
+		}
+		log.Debugf("Retrying aborted creation of profile %s", profile.ProfileId)
+		if moved {
+			if err = stage.uncommit(); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		stage.rollback()
+		return err
+	}
+	return nil
+}
+
+func (store *dgraphStore) FailProfile(failed *TenncorProfile, cause error) error {
+	if err := store.discardStaged(failed.ProfileId); err != nil {
+		return err
+	}
+	return store.withTx(func(tx *Txn) error {
+		profile, err := queryProfileRecord(tx, failed.ProfileId)
+		if errors.Is(err, ErrNotFound) {
+			profile = failed
+		} else if err != nil {
+			return err
+		}
+		profile.Status = ProfileFailed
+		profile.Error = cause.Error()
+		_, err = CreateNode(tx, profile)
+		return err
+	})
+}
+
+func (store *dgraphStore) GetProfile(profileId string) (*TenncorProfile, error) {
+	var profile *TenncorProfile
+	if err := store.withTx(func(tx *Txn) (err error) {
+		profile, err = queryProfileRecord(tx, profileId)
+		return
+	}); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+func (store *dgraphStore) ListProfileRecords(query *ProfileQuery) ([]*TenncorProfile, error) {
+	var profiles []*TenncorProfile
+	if err := store.withTx(func(tx *Txn) error {
+		q, paged, err := profileRecordsQuery(query)
+		if err != nil {
+			return err
+		}
+		b, err := QueryNode(tx, q)
+		if err != nil {
+			return err
+		}
+		response := make(map[string][]*TenncorProfile)
+		if err = json.Unmarshal(b, &response); err != nil {
+			return err
+		}
+		profiles = response["profiles"]
+		if !paged {
+			profiles = query.apply(profiles)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+// profileRecordsQuery looks up records matching query, pages are left to
+// the caller when dgraph can't filter names too short for trigrams
+func profileRecordsQuery(query *ProfileQuery) (string, bool, error) {
+	if query == nil {
+		return fmt.Sprintf(profileRecordsLookupFmt, "", ""), true, nil
+	}
+	var filters []string
+	for _, tag := range query.Tags {
+		tb, err := json.Marshal(tag)
+		if err != nil {
+			return "", false, err
+		}
+		filters = append(filters, fmt.Sprintf("eq(tags, %s)", tb))
+	}
+	paged := len([]rune(query.NameContains)) == 0 ||
+		len([]rune(query.NameContains)) >= minTrigramLength
+	if query.NameContains != "" && paged {
+		pattern := strings.ReplaceAll(regexp.QuoteMeta(query.NameContains), "/", `\/`)
+		filters = append(filters, fmt.Sprintf("regexp(name, /%s/i)", pattern))
+	}
+	if query.ProducerName != "" {
+		pb, err := json.Marshal(query.ProducerName)
+		if err != nil {
+			return "", false, err
+		}
+		filters = append(filters, fmt.Sprintf("eq(producer_name, %s)", pb))
+	}
+	if !query.CreatedAfter.IsZero() {
+		filters = append(filters, fmt.Sprintf(`ge(created_at, "%s")`,
+			query.CreatedAfter.UTC().Format(time.RFC3339Nano)))
+	}
+	if !query.CreatedBefore.IsZero() {
+		filters = append(filters, fmt.Sprintf(`lt(created_at, "%s")`,
+			query.CreatedBefore.UTC().Format(time.RFC3339Nano)))
+	}
+	order := "orderdesc"
+	if query.Ascending {
+		order = "orderasc"
+	}
+	args := fmt.Sprintf(", %s: %s, orderasc: profile_id", order, query.orderBy())
+	if paged {
+		if query.Limit > 0 {
+			args += fmt.Sprintf(", first: %d", query.Limit)
+		}
+		if query.Offset > 0 {
+			args += fmt.Sprintf(", offset: %d", query.Offset)
+		}
+	}
+	var filter string
+	if len(filters) > 0 {
+		filter = fmt.Sprintf(" @filter(%s)", strings.Join(filters, " AND "))
+	}
+	return fmt.Sprintf(profileRecordsLookupFmt, args, filter), paged, nil
+}
+
+func (store *dgraphStore) SetProfilePinned(profileId string, pinned bool) error {
+	return store.withTx(func(tx *Txn) error {
+		profile, err := queryProfileRecord(tx, profileId)
+		if err != nil {
+			return err
+		}
+		b, err := json.Marshal(map[string]interface{}{
+			"uid":    profile.Uid,
+			"pinned": pinned,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = tx.Mutate(&api.Mutation{SetJson: b})
+		return err
+	})
+}
+
+func queryProfileRecord(tx *Txn, profileId string) (*TenncorProfile, error) {
+	b, err := QueryNodeWithVars(tx, profileRecordLookup, map[string]string{"$id": profileId})
+	if err != nil {
+		return nil, err
+	}
+	response := make(map[string][]*TenncorProfile)
+	if err = json.Unmarshal(b, &response); err != nil {
+		return nil, err
+	}
+	profiles := response["profiles"]
+	if len(profiles) == 0 {
+		return nil, fmt.Errorf("profile %s %w", profileId, ErrNotFound)
+	}
+	return profiles[0], nil
+}
+
+func newDgraphNode(node *TenncorNode, uids map[string]string) *dgraphNode {
+	args := make([]*uidEntry, 0, len(node.Args))
+	for _, arg := range node.Args {
+		if arg == nil {
+			continue
+		}
+		uid, ok := uids[strings.TrimPrefix(arg.Uid, "_:")]
+		if !ok {
+			uid = arg.Uid
+		}
+		args = append(args, &uidEntry{Uid: uid})
+	}
+	return &dgraphNode{TenncorNode: node, Args: args}
+}
+
+// linkAnnotations points every annotation under roots to an existing
+// annotation with the same hash, creating ones that don't exist yet
+func linkAnnotations(tx *Txn, roots []*TenncorNode) error {
+	byHash := make(map[string][]*Annotation)
+	for _, node := range FlattenNodes(roots) {
+		for _, annotation := range node.Annotations {
+			byHash[annotation.Id] = append(byHash[annotation.Id], annotation)
+		}
+	}
+	if len(byHash) == 0 {
+		return nil
+	}
+	hashes := make([]string, 0, len(byHash))
+	for hash := range byHash {
+		hashes = append(hashes, hash)
+	}
+	hb, err := json.Marshal(hashes)
+	if err != nil {
+		return err
+	}
+	b, err := QueryNode(tx, fmt.Sprintf(annotationsLookupFmt, hb))
+	if err != nil {
+		return err
+	}
+	response := make(map[string][]*Annotation)
+	if err = json.Unmarshal(b, &response); err != nil {
+		return err
+	}
+	uids := make(map[string]string)
+	for _, existing := range response["annotations"] {
+		uids[existing.Id] = existing.Uid
+	}
+	var missing []*Annotation
+	for hash, annotations := range byHash {
+		if _, ok := uids[hash]; !ok {
+			missing = append(missing, annotations[0])
+		}
+	}
+	if len(missing) > 0 {
+		pb, err := json.Marshal(missing)
+		if err != nil {
+			return err
+		}
+		res, err := tx.Mutate(&api.Mutation{SetJson: pb})
+		if err != nil {
+			return err
+		}
+		// blank node uids are named by the annotation hash
+		for _, annotation := range missing {
+			uids[annotation.Id] = res.GetUids()[annotation.Id]
+		}
+	}
+	for hash, annotations := range byHash {
+		for _, annotation := range annotations {
+			annotation.Uid = uids[hash]
+		}
+	}
+	return nil
+}
+
+func (store *dgraphStore) GetProfileNodes(profileId string) ([]*TenncorNode, error) {
+	var nodes []*TenncorNode
+	if err := store.withTx(func(tx *Txn) (err error) {
+		var (
+			b        []byte
+			response = make(map[string][]*TenncorNode)
+			ok       bool
+		)
+		b, err = QueryNodeWithVars(tx, nodesLookup, map[string]string{"$id": profileId})
+		if err != nil {
+			return
+		}
+		if err = json.Unmarshal(b, &response); err != nil {
+			return
+		}
+		if nodes, ok = response["nodes"]; !ok {
+			err = fmt.Errorf("invalid response from dgraph: %s", string(b))
+		}
+		return
+	}); err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+func (store *dgraphStore) DeleteProfile(profileId string) (*DeletedProfile, error) {
+	deleted := &DeletedProfile{}
+	if err := store.withTx(func(tx *Txn) error {
+		b, err := QueryNodeWithVars(tx, deletionsLookup, map[string]string{"$id": profileId})
+		if err != nil {
+			return err
+		}
+		response := make(map[string][]*deletionEntry)
+		if err = json.Unmarshal(b, &response); err != nil {
+			return err
+		}
+		var deletions []map[string]interface{}
+		for _, node := range response["nodes"] {
+			deletions = append(deletions, deletion(node.Uid, nodePredicates))
+			deleted.Nodes++
+			deleted.AnnotationEdges += node.Annotations
+		}
+		for _, profile := range response["profiles"] {
+			deletions = append(deletions, deletion(profile.Uid, profilePredicates))
+		}
+		if len(deletions) == 0 {
+			return nil
+		}
+		pb, err := json.Marshal(deletions)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Mutate(&api.Mutation{DeleteJson: pb})
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	var err error
+	if deleted.Blobs, deleted.BlobBytes, err = store.deleteBlobs(profileId); err != nil {
+		return nil, err
+	}
+	return deleted, nil
+}
+
+// deletion removes every value of predicates from uid
+func deletion(uid string, predicates []string) map[string]interface{} {
+	out := map[string]interface{}{"uid": uid}
+	for _, predicate := range predicates {
+		out[predicate] = nil
+	}
+	return out
+}
@@ -0,0 +1,115 @@
+package data
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	OrderCreatedAt    = "created_at"
+	OrderNodeCount    = "node_count"
+	OrderTotalRuntime = "total_runtime"
+)
+
+type (
+	// ProfileQuery filters, orders and pages profile records,
+	// zero fields don't filter and a zero Limit is unbounded
+	ProfileQuery struct {
+		// Tags must all be on a profile
+		Tags []string
+		// NameContains matches names case-insensitively
+		NameContains  string
+		ProducerName  string
+		CreatedAfter  time.Time
+		CreatedBefore time.Time
+		// OrderBy is one of the Order predicates, by creation when empty
+		OrderBy   string
+		Ascending bool
+		Offset    int
+		Limit     int
+	}
+)
+
+func (q *ProfileQuery) orderBy() string {
+	if q == nil || q.OrderBy == "" {
+		return OrderCreatedAt
+	}
+	return q.OrderBy
+}
+
+func (q *ProfileQuery) matches(profile *TenncorProfile) bool {
+	if q == nil {
+		return true
+	}
+	for _, tag := range q.Tags {
+		found := false
+		for _, ptag := range profile.Tags {
+			if ptag == tag {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if q.NameContains != "" && !strings.Contains(
+		strings.ToLower(profile.Name), strings.ToLower(q.NameContains)) {
+		return false
+	}
+	if q.ProducerName != "" && profile.ProducerName != q.ProducerName {
+		return false
+	}
+	if !q.CreatedAfter.IsZero() && profile.CreatedAt.Before(q.CreatedAfter) {
+		return false
+	}
+	if !q.CreatedBefore.IsZero() && !profile.CreatedAt.Before(q.CreatedBefore) {
+		return false
+	}
+	return true
+}
+
+// apply filters, orders then pages profiles in memory
+func (q *ProfileQuery) apply(profiles []*TenncorProfile) []*TenncorProfile {
+	var out []*TenncorProfile
+	for _, profile := range profiles {
+		if q.matches(profile) {
+			out = append(out, profile)
+		}
+	}
+	orderBy := q.orderBy()
+	ascending := q != nil && q.Ascending
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if ascending {
+			a, b = b, a
+		}
+		switch orderBy {
+		case OrderNodeCount:
+			if a.NodeCount != b.NodeCount {
+				return a.NodeCount > b.NodeCount
+			}
+		case OrderTotalRuntime:
+			if a.TotalRuntime != b.TotalRuntime {
+				return a.TotalRuntime > b.TotalRuntime
+			}
+		default:
+			if !a.CreatedAt.Equal(b.CreatedAt) {
+				return a.CreatedAt.After(b.CreatedAt)
+			}
+		}
+		return out[i].ProfileId < out[j].ProfileId
+	})
+	if q == nil {
+		return out
+	}
+	if q.Offset >= len(out) {
+		return nil
+	}
+	out = out[q.Offset:]
+	if q.Limit > 0 && q.Limit < len(out) {
+		out = out[:q.Limit]
+	}
+	return out
+}
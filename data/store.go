@@ -0,0 +1,69 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mingkaic/accretion/proto/storage"
+)
+
+var (
+	ErrNotFound = errors.New("not found")
+	// ErrNotReady is returned while a store can't reach its database
+	ErrNotReady = errors.New("not ready")
+)
+
+type (
+	// Store is the persistence backend behind graph profiles
+	Store interface {
+		// CreateProfile saves profile with every node reachable from roots,
+		// blobs by node id and blobs staged for the profile, discarding
+		// blobs of nodes not saved, either all of it is saved or none of it
+		CreateProfile(profile *TenncorProfile, roots []*TenncorNode, blobs map[string]*storage.BlobStorage) error
+		// StageBlob writes a blob ahead of CreateProfile, so large profiles
+		// need not hold every blob in memory at once
+		StageBlob(profileId, id string, blob *storage.BlobStorage) error
+		// FailProfile records that profile failed ingestion because of cause,
+		// keeping any record already stored under its id and discarding staged blobs
+		FailProfile(profile *TenncorProfile, cause error) error
+		GetProfile(profileId string) (*TenncorProfile, error)
+		// ListProfileRecords lists records matching query, a nil query lists everything
+		ListProfileRecords(query *ProfileQuery) ([]*TenncorProfile, error)
+		SetProfilePinned(profileId string, pinned bool) error
+		GetProfileNodes(profileId string) ([]*TenncorNode, error)
+		LoadBlob(profileId, id string) (*storage.BlobStorage, error)
+		// DeleteProfile removes everything saved for profileId,
+		// deleting a missing profile removes nothing without error
+		DeleteProfile(profileId string) (*DeletedProfile, error)
+		// Ready checks the store can serve requests now within ctx, otherwise is an ErrNotReady
+		Ready(ctx context.Context) error
+		Close() error
+	}
+
+	// DeletedProfile counts what deleting a profile removed
+	DeletedProfile struct {
+		Nodes           int
+		AnnotationEdges int
+		Blobs           int
+		BlobBytes       int64
+	}
+)
+
+// NodeErrors maps node ids to the errors saving them
+type NodeErrors map[string]error
+
+func (errs NodeErrors) Error() string {
+	ids := make([]string, 0, len(errs))
+	for id := range errs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	msgs := make([]string, len(ids))
+	for i, id := range ids {
+		msgs[i] = fmt.Sprintf("node %s: %v", id, errs[id])
+	}
+	return fmt.Sprintf("%d nodes failed: %s", len(errs), strings.Join(msgs, "; "))
+}
@@ -2,6 +2,7 @@ package data
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/dgraph-io/dgo/v200"
@@ -9,75 +10,64 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+const txnTimeout = 500 * time.Second
+
 type (
-	// dgo transactions aren't thread-safe, so store in array until
-	// commit/discard where array is cleared
+	// Txn wraps a single dgraph transaction, so every query and mutation
+	// made through it commits or discards together
 	Txn struct {
-		client *dgo.Dgraph
-		txs    []*dgo.Txn
+		mu  sync.Mutex
+		txn *dgo.Txn
 	}
 )
 
-func WithTx(txFn func(*Txn) error) (err error) {
-	dg, cancel := getDgraphClient()
-	defer cancel()
-	tx := &Txn{
-		client: dg,
-		txs:    make([]*dgo.Txn, 0),
-	}
+// WithTx runs txFn in a new transaction of dg,
+// committing unless txFn fails
+func WithTx(dg *dgo.Dgraph, txFn func(*Txn) error) (err error) {
+	tx := &Txn{txn: dg.NewTxn()}
 
-	err = txFn(tx)
-	if err != nil {
-		log.Debugf("Transaction failed (discarding: %+v", err)
-		tx.Discard()
-	} else {
-		tx.Commit()
+	if err = txFn(tx); err != nil {
+		log.Debugf("Transaction failed (discarding): %+v", err)
+		if derr := tx.Discard(); derr != nil {
+			log.Errorf("Failed to discard transaction: %+v", derr)
+		}
+		return
 	}
-	return
+	return tx.Commit()
 }
 
 func (tx *Txn) Query(q string) (*api.Response, error) {
-	transx := tx.client.NewTxn()
-	tx.txs = append(tx.txs, transx)
-	ctx := context.Background()
-	clientDeadline := time.Now().Add(time.Duration(500) * time.Second)
-	ctx, cancel := context.WithDeadline(ctx, clientDeadline)
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), txnTimeout)
+	defer cancel()
+	return tx.txn.Query(ctx, q)
+}
+
+func (tx *Txn) QueryWithVars(q string, vars map[string]string) (*api.Response, error) {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), txnTimeout)
 	defer cancel()
-	return transx.Query(ctx, q)
+	return tx.txn.QueryWithVars(ctx, q, vars)
 }
 
 func (tx *Txn) Mutate(mu *api.Mutation) (*api.Response, error) {
-	tranx := tx.client.NewTxn()
-	tx.txs = append(tx.txs, tranx)
-	ctx := context.Background()
-	clientDeadline := time.Now().Add(time.Duration(500) * time.Second)
-	ctx, cancel := context.WithDeadline(ctx, clientDeadline)
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), txnTimeout)
 	defer cancel()
-	return tranx.Mutate(ctx, mu)
+	return tx.txn.Mutate(ctx, mu)
 }
 
 func (tx *Txn) Commit() error {
-	defer tx.clear()
-	for _, t := range tx.txs {
-		ctx := context.Background()
-		if err := t.Commit(ctx); err != nil {
-			return err
-		}
-	}
-	return nil
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	return tx.txn.Commit(context.Background())
 }
 
 func (tx *Txn) Discard() error {
-	defer tx.clear()
-	for _, t := range tx.txs {
-		ctx := context.Background()
-		if err := t.Discard(ctx); err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-func (tx *Txn) clear() {
-	tx.txs = make([]*dgo.Txn, 0)
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	return tx.txn.Discard(context.Background())
 }
@@ -1,36 +1,51 @@
 package main
 
 import (
-	"flag"
+	"fmt"
 	"os"
 
 	"github.com/mingkaic/accretion/api"
+	"github.com/mingkaic/accretion/config"
+	"github.com/mingkaic/accretion/data"
+	"github.com/mingkaic/accretion/service"
 	log "github.com/sirupsen/logrus"
 	"github.com/zenazn/goji/bind"
 	"github.com/zenazn/goji/graceful"
 	"google.golang.org/grpc"
 )
 
-const (
-	grpcAddr = "localhost:8069"
-	httpAddr = "localhost:8071"
+var (
+	cfg config.Config
 )
 
 func init() {
-	var lvl string
-
-	flag.StringVar(&lvl, "log_level", "debug", "Log level")
-	flag.Parse()
-
-	log_level, err := log.ParseLevel(lvl)
+	var err error
+	if cfg, err = config.Load(os.Args[0], os.Args[1:]); err != nil {
+		log.Fatal(err)
+	}
+	log_level, err := log.ParseLevel(cfg.LogLevel)
 	if nil != err {
 		panic(err)
 	}
 	log.SetLevel(log_level)
 }
 
+func newStore() (data.Store, error) {
+	switch cfg.Store {
+	case config.DgraphBackend:
+		return data.NewDgraphStore(cfg.Dgraph)
+	case config.EmbeddedBackend:
+		return data.NewBoltStore(cfg.DataDir)
+	}
+	return nil, fmt.Errorf("unknown storage backend %s", cfg.Store)
+}
+
 func main() {
-	log.Infof("Serving grpc on %s, http on %s", grpcAddr, httpAddr)
+	store, err := newStore()
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Infof("Serving grpc on %s, http on %s", cfg.GRPCAddr, cfg.HTTPAddr)
 
 	var (
 		grpcOpts []grpc.ServerOption
@@ -40,9 +55,8 @@ func main() {
 		failed            bool
 		gracefullyStopped bool
 	)
-	grpcOpts = append(grpcOpts, grpc.MaxRecvMsgSize(1024*1024*64)) // 32MB
 	dialOpts = append(dialOpts, grpc.WithInsecure())
-	app := api.NewAccretionAPI()
+	app := api.NewAccretionAPI(cfg, store)
 
 	graceful.HandleSignals()
 	bind.Ready()
@@ -50,12 +64,25 @@ func main() {
 		gracefullyStopped = true
 		log.Info("Server received signal, gracefully stopping.")
 	})
+	retention := service.RetentionPolicy{
+		MaxAge:              cfg.Retention.MaxAge,
+		MaxProfilesPerModel: cfg.Retention.MaxProfilesPerModel,
+		MaxBlobBytes:        cfg.Retention.MaxBlobBytes,
+	}
+	if retention.Enabled() {
+		stopGC := make(chan struct{})
+		graceful.PreHook(func() { close(stopGC) })
+		go service.NewCollector(store, retention).Run(cfg.Retention.GCInterval, stopGC)
+	}
 	graceful.PostHook(func() {
+		if err := store.Close(); err != nil {
+			log.Errorf("failed to close store: %v", err)
+		}
 		log.Info("Server stopped")
 	})
 
 	errs := make(chan error, 2)
-	app.Run(httpAddr, grpcAddr, errs, grpcOpts, api.HTTPOpts{DialOpts: dialOpts})
+	app.Run(errs, grpcOpts, api.HTTPOpts{DialOpts: dialOpts})
 
 	for err := range errs {
 		if err != nil {
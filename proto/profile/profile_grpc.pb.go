@@ -20,7 +20,13 @@ const _ = grpc.SupportPackageIsVersion7
 type TenncorProfileServiceClient interface {
 	ListProfile(ctx context.Context, in *ListProfileRequest, opts ...grpc.CallOption) (*ListProfileResponse, error)
 	GetProfile(ctx context.Context, in *GetProfileRequest, opts ...grpc.CallOption) (*GetProfileResponse, error)
+	// over http, POST /v1/profiles creates profiles from a multipart upload
+	// of an onnx model file and an optional runtimes file
 	CreateProfile(ctx context.Context, in *CreateProfileRequest, opts ...grpc.CallOption) (*CreateProfileResponse, error)
+	StreamCreateProfile(ctx context.Context, opts ...grpc.CallOption) (TenncorProfileService_StreamCreateProfileClient, error)
+	DeleteProfile(ctx context.Context, in *DeleteProfileRequest, opts ...grpc.CallOption) (*DeleteProfileResponse, error)
+	SetProfilePinned(ctx context.Context, in *SetProfilePinnedRequest, opts ...grpc.CallOption) (*SetProfilePinnedResponse, error)
+	GetTensorData(ctx context.Context, in *GetTensorDataRequest, opts ...grpc.CallOption) (*GetTensorDataResponse, error)
 }
 
 type tenncorProfileServiceClient struct {
@@ -58,13 +64,80 @@ func (c *tenncorProfileServiceClient) CreateProfile(ctx context.Context, in *Cre
 	return out, nil
 }
 
+func (c *tenncorProfileServiceClient) StreamCreateProfile(ctx context.Context, opts ...grpc.CallOption) (TenncorProfileService_StreamCreateProfileClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TenncorProfileService_ServiceDesc.Streams[0], "/tenncor_profile.TenncorProfileService/StreamCreateProfile", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &tenncorProfileServiceStreamCreateProfileClient{stream}
+	return x, nil
+}
+
+type TenncorProfileService_StreamCreateProfileClient interface {
+	Send(*StreamCreateProfileRequest) error
+	CloseAndRecv() (*CreateProfileResponse, error)
+	grpc.ClientStream
+}
+
+type tenncorProfileServiceStreamCreateProfileClient struct {
+	grpc.ClientStream
+}
+
+func (x *tenncorProfileServiceStreamCreateProfileClient) Send(m *StreamCreateProfileRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *tenncorProfileServiceStreamCreateProfileClient) CloseAndRecv() (*CreateProfileResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(CreateProfileResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *tenncorProfileServiceClient) DeleteProfile(ctx context.Context, in *DeleteProfileRequest, opts ...grpc.CallOption) (*DeleteProfileResponse, error) {
+	out := new(DeleteProfileResponse)
+	err := c.cc.Invoke(ctx, "/tenncor_profile.TenncorProfileService/DeleteProfile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tenncorProfileServiceClient) SetProfilePinned(ctx context.Context, in *SetProfilePinnedRequest, opts ...grpc.CallOption) (*SetProfilePinnedResponse, error) {
+	out := new(SetProfilePinnedResponse)
+	err := c.cc.Invoke(ctx, "/tenncor_profile.TenncorProfileService/SetProfilePinned", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tenncorProfileServiceClient) GetTensorData(ctx context.Context, in *GetTensorDataRequest, opts ...grpc.CallOption) (*GetTensorDataResponse, error) {
+	out := new(GetTensorDataResponse)
+	err := c.cc.Invoke(ctx, "/tenncor_profile.TenncorProfileService/GetTensorData", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TenncorProfileServiceServer is the server API for TenncorProfileService service.
 // All implementations must embed UnimplementedTenncorProfileServiceServer
 // for forward compatibility
 type TenncorProfileServiceServer interface {
 	ListProfile(context.Context, *ListProfileRequest) (*ListProfileResponse, error)
 	GetProfile(context.Context, *GetProfileRequest) (*GetProfileResponse, error)
+	// over http, POST /v1/profiles creates profiles from a multipart upload
+	// of an onnx model file and an optional runtimes file
 	CreateProfile(context.Context, *CreateProfileRequest) (*CreateProfileResponse, error)
+	StreamCreateProfile(TenncorProfileService_StreamCreateProfileServer) error
+	DeleteProfile(context.Context, *DeleteProfileRequest) (*DeleteProfileResponse, error)
+	SetProfilePinned(context.Context, *SetProfilePinnedRequest) (*SetProfilePinnedResponse, error)
+	GetTensorData(context.Context, *GetTensorDataRequest) (*GetTensorDataResponse, error)
 	mustEmbedUnimplementedTenncorProfileServiceServer()
 }
 
@@ -81,6 +154,18 @@ func (UnimplementedTenncorProfileServiceServer) GetProfile(context.Context, *Get
 func (UnimplementedTenncorProfileServiceServer) CreateProfile(context.Context, *CreateProfileRequest) (*CreateProfileResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateProfile not implemented")
 }
+func (UnimplementedTenncorProfileServiceServer) StreamCreateProfile(TenncorProfileService_StreamCreateProfileServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamCreateProfile not implemented")
+}
+func (UnimplementedTenncorProfileServiceServer) DeleteProfile(context.Context, *DeleteProfileRequest) (*DeleteProfileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteProfile not implemented")
+}
+func (UnimplementedTenncorProfileServiceServer) SetProfilePinned(context.Context, *SetProfilePinnedRequest) (*SetProfilePinnedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetProfilePinned not implemented")
+}
+func (UnimplementedTenncorProfileServiceServer) GetTensorData(context.Context, *GetTensorDataRequest) (*GetTensorDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTensorData not implemented")
+}
 func (UnimplementedTenncorProfileServiceServer) mustEmbedUnimplementedTenncorProfileServiceServer() {}
 
 // UnsafeTenncorProfileServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -148,6 +233,86 @@ func _TenncorProfileService_CreateProfile_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TenncorProfileService_StreamCreateProfile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TenncorProfileServiceServer).StreamCreateProfile(&tenncorProfileServiceStreamCreateProfileServer{stream})
+}
+
+type TenncorProfileService_StreamCreateProfileServer interface {
+	SendAndClose(*CreateProfileResponse) error
+	Recv() (*StreamCreateProfileRequest, error)
+	grpc.ServerStream
+}
+
+type tenncorProfileServiceStreamCreateProfileServer struct {
+	grpc.ServerStream
+}
+
+func (x *tenncorProfileServiceStreamCreateProfileServer) SendAndClose(m *CreateProfileResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *tenncorProfileServiceStreamCreateProfileServer) Recv() (*StreamCreateProfileRequest, error) {
+	m := new(StreamCreateProfileRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _TenncorProfileService_DeleteProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TenncorProfileServiceServer).DeleteProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tenncor_profile.TenncorProfileService/DeleteProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TenncorProfileServiceServer).DeleteProfile(ctx, req.(*DeleteProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TenncorProfileService_SetProfilePinned_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetProfilePinnedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TenncorProfileServiceServer).SetProfilePinned(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tenncor_profile.TenncorProfileService/SetProfilePinned",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TenncorProfileServiceServer).SetProfilePinned(ctx, req.(*SetProfilePinnedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TenncorProfileService_GetTensorData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTensorDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TenncorProfileServiceServer).GetTensorData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tenncor_profile.TenncorProfileService/GetTensorData",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TenncorProfileServiceServer).GetTensorData(ctx, req.(*GetTensorDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TenncorProfileService_ServiceDesc is the grpc.ServiceDesc for TenncorProfileService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -167,7 +332,25 @@ var TenncorProfileService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateProfile",
 			Handler:    _TenncorProfileService_CreateProfile_Handler,
 		},
+		{
+			MethodName: "DeleteProfile",
+			Handler:    _TenncorProfileService_DeleteProfile_Handler,
+		},
+		{
+			MethodName: "SetProfilePinned",
+			Handler:    _TenncorProfileService_SetProfilePinned_Handler,
+		},
+		{
+			MethodName: "GetTensorData",
+			Handler:    _TenncorProfileService_GetTensorData_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamCreateProfile",
+			Handler:       _TenncorProfileService_StreamCreateProfile_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "profile/profile.proto",
 }
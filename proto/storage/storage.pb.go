@@ -20,14 +20,72 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type BlobVersion int32
+
+const (
+	// values widened to doubles in data
+	BlobVersion_FLOAT64_DATA BlobVersion = 0
+	// values in their native dtype as little-endian bytes in raw_data
+	BlobVersion_RAW_DATA BlobVersion = 1
+)
+
+// Enum value maps for BlobVersion.
+var (
+	BlobVersion_name = map[int32]string{
+		0: "FLOAT64_DATA",
+		1: "RAW_DATA",
+	}
+	BlobVersion_value = map[string]int32{
+		"FLOAT64_DATA": 0,
+		"RAW_DATA":     1,
+	}
+)
+
+func (x BlobVersion) Enum() *BlobVersion {
+	p := new(BlobVersion)
+	*p = x
+	return p
+}
+
+func (x BlobVersion) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BlobVersion) Descriptor() protoreflect.EnumDescriptor {
+	return file_storage_storage_proto_enumTypes[0].Descriptor()
+}
+
+func (BlobVersion) Type() protoreflect.EnumType {
+	return &file_storage_storage_proto_enumTypes[0]
+}
+
+func (x BlobVersion) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BlobVersion.Descriptor instead.
+func (BlobVersion) EnumDescriptor() ([]byte, []int) {
+	return file_storage_storage_proto_rawDescGZIP(), []int{0}
+}
+
 type BlobStorage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
+	// values of FLOAT64_DATA blobs,
+	// complex values interleave real and imaginary parts
 	Data         []float64 `protobuf:"fixed64,1,rep,packed,name=data,proto3" json:"data,omitempty"`
 	Indices      []int32   `protobuf:"varint,2,rep,packed,name=indices,proto3" json:"indices,omitempty"`
 	OuterIndices []int64   `protobuf:"varint,3,rep,packed,name=outer_indices,json=outerIndices,proto3" json:"outer_indices,omitempty"`
+	Shape        []uint64  `protobuf:"varint,4,rep,packed,name=shape,proto3" json:"shape,omitempty"`
+	// onnx TensorProto.DataType of the original tensor
+	Dtype int32 `protobuf:"varint,5,opt,name=dtype,proto3" json:"dtype,omitempty"`
+	// values of string tensors
+	Strings [][]byte    `protobuf:"bytes,6,rep,name=strings,proto3" json:"strings,omitempty"`
+	Version BlobVersion `protobuf:"varint,7,opt,name=version,proto3,enum=accretion_storage.BlobVersion" json:"version,omitempty"`
+	// values of RAW_DATA blobs laid out like onnx TensorProto.raw_data
+	RawData []byte `protobuf:"bytes,8,opt,name=raw_data,json=rawData,proto3" json:"raw_data,omitempty"`
 }
 
 func (x *BlobStorage) Reset() {
@@ -83,22 +141,69 @@ func (x *BlobStorage) GetOuterIndices() []int64 {
 	return nil
 }
 
+func (x *BlobStorage) GetShape() []uint64 {
+	if x != nil {
+		return x.Shape
+	}
+	return nil
+}
+
+func (x *BlobStorage) GetDtype() int32 {
+	if x != nil {
+		return x.Dtype
+	}
+	return 0
+}
+
+func (x *BlobStorage) GetStrings() [][]byte {
+	if x != nil {
+		return x.Strings
+	}
+	return nil
+}
+
+func (x *BlobStorage) GetVersion() BlobVersion {
+	if x != nil {
+		return x.Version
+	}
+	return BlobVersion_FLOAT64_DATA
+}
+
+func (x *BlobStorage) GetRawData() []byte {
+	if x != nil {
+		return x.RawData
+	}
+	return nil
+}
+
 var File_storage_storage_proto protoreflect.FileDescriptor
 
 var file_storage_storage_proto_rawDesc = []byte{
 	0x0a, 0x15, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67,
 	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x11, 0x61, 0x63, 0x63, 0x72, 0x65, 0x74, 0x69,
-	0x6f, 0x6e, 0x5f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x22, 0x60, 0x0a, 0x0b, 0x42, 0x6c,
-	0x6f, 0x62, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74,
-	0x61, 0x18, 0x01, 0x20, 0x03, 0x28, 0x01, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x18, 0x0a,
-	0x07, 0x69, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x05, 0x52, 0x07,
-	0x69, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x6f, 0x75, 0x74, 0x65, 0x72,
-	0x5f, 0x69, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x03, 0x52, 0x0c,
-	0x6f, 0x75, 0x74, 0x65, 0x72, 0x49, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x42, 0x2d, 0x5a, 0x2b,
-	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x69, 0x6e, 0x67, 0x6b,
-	0x61, 0x69, 0x63, 0x2f, 0x61, 0x63, 0x63, 0x72, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x6f, 0x6e, 0x5f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x22, 0xfb, 0x01, 0x0a, 0x0b, 0x42,
+	0x6c, 0x6f, 0x62, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x01, 0x20, 0x03, 0x28, 0x01, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x18,
+	0x0a, 0x07, 0x69, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x05, 0x52,
+	0x07, 0x69, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x6f, 0x75, 0x74, 0x65,
+	0x72, 0x5f, 0x69, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x03, 0x52,
+	0x0c, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x49, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x73, 0x68, 0x61, 0x70, 0x65, 0x18, 0x04, 0x20, 0x03, 0x28, 0x04, 0x52, 0x05, 0x73, 0x68,
+	0x61, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x64, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x74, 0x72,
+	0x69, 0x6e, 0x67, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x07, 0x73, 0x74, 0x72, 0x69,
+	0x6e, 0x67, 0x73, 0x12, 0x38, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x61, 0x63, 0x63, 0x72, 0x65, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x0a,
+	0x08, 0x72, 0x61, 0x77, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x07, 0x72, 0x61, 0x77, 0x44, 0x61, 0x74, 0x61, 0x2a, 0x2d, 0x0a, 0x0b, 0x42, 0x6c, 0x6f, 0x62,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x0c, 0x46, 0x4c, 0x4f, 0x41, 0x54,
+	0x36, 0x34, 0x5f, 0x44, 0x41, 0x54, 0x41, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x52, 0x41, 0x57,
+	0x5f, 0x44, 0x41, 0x54, 0x41, 0x10, 0x01, 0x42, 0x2d, 0x5a, 0x2b, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x69, 0x6e, 0x67, 0x6b, 0x61, 0x69, 0x63, 0x2f, 0x61,
+	0x63, 0x63, 0x72, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73,
+	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -113,16 +218,19 @@ func file_storage_storage_proto_rawDescGZIP() []byte {
 	return file_storage_storage_proto_rawDescData
 }
 
+var file_storage_storage_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
 var file_storage_storage_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
 var file_storage_storage_proto_goTypes = []interface{}{
-	(*BlobStorage)(nil), // 0: accretion_storage.BlobStorage
+	(BlobVersion)(0),    // 0: accretion_storage.BlobVersion
+	(*BlobStorage)(nil), // 1: accretion_storage.BlobStorage
 }
 var file_storage_storage_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0, // 0: accretion_storage.BlobStorage.version:type_name -> accretion_storage.BlobVersion
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_storage_storage_proto_init() }
@@ -149,13 +257,14 @@ func file_storage_storage_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_storage_storage_proto_rawDesc,
-			NumEnums:      0,
+			NumEnums:      1,
 			NumMessages:   1,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_storage_storage_proto_goTypes,
 		DependencyIndexes: file_storage_storage_proto_depIdxs,
+		EnumInfos:         file_storage_storage_proto_enumTypes,
 		MessageInfos:      file_storage_storage_proto_msgTypes,
 	}.Build()
 	File_storage_storage_proto = out.File
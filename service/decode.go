@@ -0,0 +1,237 @@
+package service
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/mingkaic/onnx_go/onnx"
+
+	"github.com/mingkaic/accretion/proto/storage"
+)
+
+var (
+	// rawSizes are the bytes per element of raw tensor data by dtype,
+	// strings are never raw
+	rawSizes = map[onnx.TensorProto_DataType]int{
+		onnx.TensorProto_DOUBLE:     8,
+		onnx.TensorProto_FLOAT:      4,
+		onnx.TensorProto_FLOAT16:    2,
+		onnx.TensorProto_BFLOAT16:   2,
+		onnx.TensorProto_INT64:      8,
+		onnx.TensorProto_UINT64:     8,
+		onnx.TensorProto_INT32:      4,
+		onnx.TensorProto_UINT32:     4,
+		onnx.TensorProto_INT16:      2,
+		onnx.TensorProto_UINT16:     2,
+		onnx.TensorProto_INT8:       1,
+		onnx.TensorProto_UINT8:      1,
+		onnx.TensorProto_BOOL:       1,
+		onnx.TensorProto_COMPLEX64:  8,
+		onnx.TensorProto_COMPLEX128: 16,
+	}
+
+	// complexParts are the dtypes of the real and imaginary parts of complex dtypes
+	complexParts = map[onnx.TensorProto_DataType]onnx.TensorProto_DataType{
+		onnx.TensorProto_COMPLEX64:  onnx.TensorProto_FLOAT,
+		onnx.TensorProto_COMPLEX128: onnx.TensorProto_DOUBLE,
+	}
+)
+
+// numElements is the product of dims, 1 for scalars
+func numElements(dims []uint64) uint64 {
+	n := uint64(1)
+	for _, d := range dims {
+		n *= d
+	}
+	return n
+}
+
+// valueWidth is the number of values per element of dtype,
+// complex elements are interleaved real and imaginary values
+func valueWidth(dtype onnx.TensorProto_DataType) uint64 {
+	if _, ok := complexParts[dtype]; ok {
+		return 2
+	}
+	return 1
+}
+
+// checkRawData checks that raw tensor bytes hold exactly count elements of dtype
+func checkRawData(dtype onnx.TensorProto_DataType, raw []byte, count uint64) error {
+	size, ok := rawSizes[dtype]
+	if !ok {
+		return fmt.Errorf("raw data is unsupported for variable type %s", dtype)
+	}
+	if uint64(len(raw)) != count*uint64(size) {
+		return fmt.Errorf("raw data has %d bytes but %d %s elements need %d",
+			len(raw), count, dtype, count*uint64(size))
+	}
+	return nil
+}
+
+// decodeRawData decodes little-endian raw tensor bytes of dtype
+func decodeRawData(dtype onnx.TensorProto_DataType, raw []byte) ([]float64, error) {
+	size, ok := rawSizes[dtype]
+	if !ok {
+		return nil, fmt.Errorf("raw data is unsupported for variable type %s", dtype)
+	}
+	if len(raw)%size != 0 {
+		return nil, fmt.Errorf("raw data has %d bytes, not a multiple of %s elements", len(raw), dtype)
+	}
+	if part, ok := complexParts[dtype]; ok {
+		return decodeRawData(part, raw)
+	}
+	out := make([]float64, len(raw)/size)
+	for i := range out {
+		b := raw[i*size : (i+1)*size]
+		switch dtype {
+		case onnx.TensorProto_DOUBLE:
+			out[i] = math.Float64frombits(binary.LittleEndian.Uint64(b))
+		case onnx.TensorProto_FLOAT:
+			out[i] = float64(math.Float32frombits(binary.LittleEndian.Uint32(b)))
+		case onnx.TensorProto_FLOAT16:
+			out[i] = halfToFloat(binary.LittleEndian.Uint16(b))
+		case onnx.TensorProto_BFLOAT16:
+			out[i] = bfloatToFloat(binary.LittleEndian.Uint16(b))
+		case onnx.TensorProto_INT64:
+			out[i] = float64(int64(binary.LittleEndian.Uint64(b)))
+		case onnx.TensorProto_UINT64:
+			out[i] = float64(binary.LittleEndian.Uint64(b))
+		case onnx.TensorProto_INT32:
+			out[i] = float64(int32(binary.LittleEndian.Uint32(b)))
+		case onnx.TensorProto_UINT32:
+			out[i] = float64(binary.LittleEndian.Uint32(b))
+		case onnx.TensorProto_INT16:
+			out[i] = float64(int16(binary.LittleEndian.Uint16(b)))
+		case onnx.TensorProto_UINT16:
+			out[i] = float64(binary.LittleEndian.Uint16(b))
+		case onnx.TensorProto_INT8:
+			out[i] = float64(int8(b[0]))
+		case onnx.TensorProto_UINT8:
+			out[i] = float64(b[0])
+		case onnx.TensorProto_BOOL:
+			if b[0] != 0 {
+				out[i] = 1
+			}
+		}
+	}
+	return out, nil
+}
+
+// typedRawData encodes values from the typed data field of the tensor's
+// dtype as little-endian raw bytes, string tensors have no raw bytes
+func typedRawData(init *onnx.TensorProto) ([]byte, error) {
+	var (
+		raw   []byte
+		dtype = onnx.TensorProto_DataType(init.GetDataType())
+		size  = rawSizes[dtype]
+	)
+	switch dtype {
+	case onnx.TensorProto_DOUBLE, onnx.TensorProto_COMPLEX128:
+		ddata := init.GetDoubleData()
+		raw = make([]byte, 8*len(ddata))
+		for i, d := range ddata {
+			binary.LittleEndian.PutUint64(raw[8*i:], math.Float64bits(d))
+		}
+	case onnx.TensorProto_FLOAT, onnx.TensorProto_COMPLEX64:
+		fdata := init.GetFloatData()
+		raw = make([]byte, 4*len(fdata))
+		for i, f := range fdata {
+			binary.LittleEndian.PutUint32(raw[4*i:], math.Float32bits(f))
+		}
+	case onnx.TensorProto_INT32, onnx.TensorProto_UINT16, onnx.TensorProto_INT16,
+		onnx.TensorProto_FLOAT16, onnx.TensorProto_BFLOAT16,
+		onnx.TensorProto_UINT8, onnx.TensorProto_INT8, onnx.TensorProto_BOOL:
+		// narrower types and half precision bits are held in int32 data
+		idata := init.GetInt32Data()
+		raw = make([]byte, size*len(idata))
+		for i, v := range idata {
+			switch size {
+			case 4:
+				binary.LittleEndian.PutUint32(raw[4*i:], uint32(v))
+			case 2:
+				binary.LittleEndian.PutUint16(raw[2*i:], uint16(v))
+			default:
+				raw[i] = byte(v)
+			}
+		}
+	case onnx.TensorProto_UINT32, onnx.TensorProto_UINT64:
+		udata := init.GetUint64Data()
+		raw = make([]byte, size*len(udata))
+		for i, u := range udata {
+			if size == 4 {
+				binary.LittleEndian.PutUint32(raw[4*i:], uint32(u))
+			} else {
+				binary.LittleEndian.PutUint64(raw[8*i:], u)
+			}
+		}
+	case onnx.TensorProto_INT64:
+		idata := init.GetInt64Data()
+		raw = make([]byte, 8*len(idata))
+		for i, v := range idata {
+			binary.LittleEndian.PutUint64(raw[8*i:], uint64(v))
+		}
+	case onnx.TensorProto_STRING:
+	default:
+		return nil, fmt.Errorf("bad variable type %s", dtype)
+	}
+	return raw, nil
+}
+
+// blobValues reads numeric values from either blob version
+func blobValues(blob *storage.BlobStorage) ([]float64, error) {
+	switch blob.GetVersion() {
+	case storage.BlobVersion_FLOAT64_DATA:
+		return blob.GetData(), nil
+	case storage.BlobVersion_RAW_DATA:
+		dtype := onnx.TensorProto_DataType(blob.GetDtype())
+		// nodes without operator data have empty blobs of undefined type
+		if dtype == onnx.TensorProto_STRING || len(blob.GetRawData()) == 0 {
+			return nil, nil
+		}
+		return decodeRawData(dtype, blob.GetRawData())
+	}
+	return nil, fmt.Errorf("unknown blob version %v", blob.GetVersion())
+}
+
+// blobHasData is whether blob holds a tensor,
+// tensors with a zero dimension hold no values but are still tensors
+func blobHasData(blob *storage.BlobStorage) bool {
+	if len(blob.GetRawData()) > 0 || len(blob.GetStrings()) > 0 || len(blob.GetData()) > 0 {
+		return true
+	}
+	if onnx.TensorProto_DataType(blob.GetDtype()) == onnx.TensorProto_UNDEFINED {
+		return false
+	}
+	shape := blob.GetShape()
+	n, err := shapeElements(shape)
+	return err == nil && len(shape) > 0 && n == 0
+}
+
+// halfToFloat converts IEEE 754 half precision bits
+func halfToFloat(h uint16) float64 {
+	var (
+		sign     = 1.
+		exponent = int(h>>10) & 0x1f
+		mantissa = float64(h & 0x3ff)
+	)
+	if h&0x8000 != 0 {
+		sign = -1
+	}
+	switch exponent {
+	case 0:
+		// subnormal
+		return sign * math.Ldexp(mantissa, -24)
+	case 0x1f:
+		if mantissa != 0 {
+			return math.NaN()
+		}
+		return math.Inf(int(sign))
+	}
+	return sign * math.Ldexp(1+mantissa/1024, exponent-15)
+}
+
+// bfloatToFloat converts bfloat16 bits, the upper half of float32 bits
+func bfloatToFloat(h uint16) float64 {
+	return float64(math.Float32frombits(uint32(h) << 16))
+}
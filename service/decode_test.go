@@ -0,0 +1,243 @@
+package service
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/mingkaic/onnx_go/onnx"
+
+	"github.com/mingkaic/accretion/proto/storage"
+)
+
+// sameValues compares decoded values exactly, matching NaNs and signed zeros
+func sameValues(got, want []float64) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if math.IsNaN(want[i]) {
+			if !math.IsNaN(got[i]) {
+				return false
+			}
+			continue
+		}
+		if got[i] != want[i] || math.Signbit(got[i]) != math.Signbit(want[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestDecodeRawData(t *testing.T) {
+	tests := []struct {
+		dtype onnx.TensorProto_DataType
+		raw   []byte
+		want  []float64
+	}{
+		{onnx.TensorProto_DOUBLE, []byte{0, 0, 0, 0, 0, 0, 0xf0, 0x3f, 0, 0, 0, 0, 0, 0, 0, 0xc0}, []float64{1, -2}},
+		{onnx.TensorProto_FLOAT, []byte{0, 0, 0x80, 0x3f, 0, 0, 0, 0xc0}, []float64{1, -2}},
+		{onnx.TensorProto_FLOAT16, []byte{0x00, 0x3c, 0x00, 0xc0}, []float64{1, -2}},
+		{onnx.TensorProto_BFLOAT16, []byte{0x80, 0x3f, 0x00, 0xc0}, []float64{1, -2}},
+		{onnx.TensorProto_INT64, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 2, 0, 0, 0, 0, 0, 0, 0}, []float64{-1, 2}},
+		{onnx.TensorProto_UINT64, []byte{0xff, 0xff, 0xff, 0xff, 0, 0, 0, 0}, []float64{math.MaxUint32}},
+		{onnx.TensorProto_INT32, []byte{0xfe, 0xff, 0xff, 0xff, 3, 0, 0, 0}, []float64{-2, 3}},
+		{onnx.TensorProto_UINT32, []byte{0xfe, 0xff, 0xff, 0xff}, []float64{math.MaxUint32 - 1}},
+		{onnx.TensorProto_INT16, []byte{0x00, 0x80, 5, 0}, []float64{math.MinInt16, 5}},
+		{onnx.TensorProto_UINT16, []byte{0xff, 0xff}, []float64{math.MaxUint16}},
+		{onnx.TensorProto_INT8, []byte{0x80, 0x7f}, []float64{math.MinInt8, math.MaxInt8}},
+		{onnx.TensorProto_UINT8, []byte{0xff, 0}, []float64{math.MaxUint8, 0}},
+		{onnx.TensorProto_BOOL, []byte{0, 1, 2}, []float64{0, 1, 1}},
+		// complex elements interleave real and imaginary parts
+		{onnx.TensorProto_COMPLEX64, []byte{0, 0, 0x80, 0x3f, 0, 0, 0, 0xc0}, []float64{1, -2}},
+		{onnx.TensorProto_COMPLEX128, []byte{0, 0, 0, 0, 0, 0, 0xf0, 0x3f, 0, 0, 0, 0, 0, 0, 0, 0xc0}, []float64{1, -2}},
+		{onnx.TensorProto_FLOAT, nil, []float64{}},
+	}
+	for _, test := range tests {
+		got, err := decodeRawData(test.dtype, test.raw)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.dtype, err)
+			continue
+		}
+		if !sameValues(got, test.want) {
+			t.Errorf("%s: decoded %v, want %v", test.dtype, got, test.want)
+		}
+	}
+}
+
+func TestDecodeRawDataErrors(t *testing.T) {
+	tests := []struct {
+		dtype onnx.TensorProto_DataType
+		raw   []byte
+		want  string
+	}{
+		{onnx.TensorProto_FLOAT, []byte{0, 0, 0}, "not a multiple"},
+		{onnx.TensorProto_DOUBLE, make([]byte, 12), "not a multiple"},
+		{onnx.TensorProto_FLOAT16, []byte{0}, "not a multiple"},
+		{onnx.TensorProto_COMPLEX64, make([]byte, 6), "not a multiple"},
+		{onnx.TensorProto_STRING, []byte("a"), "unsupported"},
+		{onnx.TensorProto_UNDEFINED, []byte{0}, "unsupported"},
+	}
+	for _, test := range tests {
+		_, err := decodeRawData(test.dtype, test.raw)
+		if err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("%s with %d bytes: got error %v, want %q", test.dtype, len(test.raw), err, test.want)
+		}
+	}
+}
+
+func TestCheckRawData(t *testing.T) {
+	tests := []struct {
+		dtype   onnx.TensorProto_DataType
+		nbytes  int
+		count   uint64
+		wantErr string
+	}{
+		{onnx.TensorProto_FLOAT, 8, 2, ""},
+		{onnx.TensorProto_FLOAT16, 6, 3, ""},
+		{onnx.TensorProto_COMPLEX128, 32, 2, ""},
+		{onnx.TensorProto_BOOL, 1, 1, ""},
+		{onnx.TensorProto_FLOAT, 8, 3, "raw data has 8 bytes but 3 FLOAT elements need 12"},
+		{onnx.TensorProto_INT64, 16, 1, "raw data has 16 bytes but 1 INT64 elements need 8"},
+		{onnx.TensorProto_COMPLEX64, 8, 2, "raw data has 8 bytes but 2 COMPLEX64 elements need 16"},
+		{onnx.TensorProto_STRING, 1, 1, "unsupported"},
+	}
+	for _, test := range tests {
+		err := checkRawData(test.dtype, make([]byte, test.nbytes), test.count)
+		if test.wantErr == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", test.dtype, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+			t.Errorf("%s: got error %v, want %q", test.dtype, err, test.wantErr)
+		}
+	}
+}
+
+func TestHalfToFloat(t *testing.T) {
+	tests := []struct {
+		bits uint16
+		want float64
+	}{
+		{0x0000, 0},
+		{0x8000, math.Copysign(0, -1)},
+		{0x3c00, 1},
+		{0xc000, -2},
+		{0x3555, 0x555p-12},
+		{0x7bff, 65504},
+		{0x0400, math.Ldexp(1, -14)},
+		// subnormals
+		{0x0001, math.Ldexp(1, -24)},
+		{0x03ff, math.Ldexp(1023, -24)},
+		{0x8001, -math.Ldexp(1, -24)},
+		{0x7c00, math.Inf(1)},
+		{0xfc00, math.Inf(-1)},
+		{0x7e00, math.NaN()},
+		{0xfc01, math.NaN()},
+	}
+	for _, test := range tests {
+		if got := halfToFloat(test.bits); !sameValues([]float64{got}, []float64{test.want}) {
+			t.Errorf("halfToFloat(%#04x) = %v, want %v", test.bits, got, test.want)
+		}
+	}
+}
+
+func TestBfloatToFloat(t *testing.T) {
+	tests := []struct {
+		bits uint16
+		want float64
+	}{
+		{0x0000, 0},
+		{0x8000, math.Copysign(0, -1)},
+		{0x3f80, 1},
+		{0xc000, -2},
+		{0x4049, 3.140625},
+		{0x0080, math.Ldexp(1, -126)},
+		// subnormals
+		{0x0001, math.Ldexp(1, -133)},
+		{0x807f, -math.Ldexp(127, -133)},
+		{0x7f80, math.Inf(1)},
+		{0xff80, math.Inf(-1)},
+		{0x7fc0, math.NaN()},
+		{0xff81, math.NaN()},
+	}
+	for _, test := range tests {
+		if got := bfloatToFloat(test.bits); !sameValues([]float64{got}, []float64{test.want}) {
+			t.Errorf("bfloatToFloat(%#04x) = %v, want %v", test.bits, got, test.want)
+		}
+	}
+}
+
+func TestTypedRawData(t *testing.T) {
+	tests := []struct {
+		init *onnx.TensorProto
+		want []float64
+	}{
+		{&onnx.TensorProto{DataType: int32(onnx.TensorProto_DOUBLE), DoubleData: []float64{1.5, -2}}, []float64{1.5, -2}},
+		{&onnx.TensorProto{DataType: int32(onnx.TensorProto_COMPLEX128), DoubleData: []float64{1, -1}}, []float64{1, -1}},
+		{&onnx.TensorProto{DataType: int32(onnx.TensorProto_FLOAT), FloatData: []float32{0.5, -3}}, []float64{0.5, -3}},
+		{&onnx.TensorProto{DataType: int32(onnx.TensorProto_COMPLEX64), FloatData: []float32{2, 4}}, []float64{2, 4}},
+		{&onnx.TensorProto{DataType: int32(onnx.TensorProto_INT32), Int32Data: []int32{-7, 7}}, []float64{-7, 7}},
+		{&onnx.TensorProto{DataType: int32(onnx.TensorProto_INT16), Int32Data: []int32{math.MinInt16, 1}}, []float64{math.MinInt16, 1}},
+		{&onnx.TensorProto{DataType: int32(onnx.TensorProto_UINT16), Int32Data: []int32{math.MaxUint16}}, []float64{math.MaxUint16}},
+		{&onnx.TensorProto{DataType: int32(onnx.TensorProto_INT8), Int32Data: []int32{-128, 127}}, []float64{-128, 127}},
+		{&onnx.TensorProto{DataType: int32(onnx.TensorProto_UINT8), Int32Data: []int32{255}}, []float64{255}},
+		{&onnx.TensorProto{DataType: int32(onnx.TensorProto_BOOL), Int32Data: []int32{0, 1}}, []float64{0, 1}},
+		// half precision bits are held in int32 data
+		{&onnx.TensorProto{DataType: int32(onnx.TensorProto_FLOAT16), Int32Data: []int32{0x3c00, 0x7c00}}, []float64{1, math.Inf(1)}},
+		{&onnx.TensorProto{DataType: int32(onnx.TensorProto_BFLOAT16), Int32Data: []int32{0xc000, 0x7fc0}}, []float64{-2, math.NaN()}},
+		{&onnx.TensorProto{DataType: int32(onnx.TensorProto_UINT32), Uint64Data: []uint64{math.MaxUint32}}, []float64{math.MaxUint32}},
+		{&onnx.TensorProto{DataType: int32(onnx.TensorProto_UINT64), Uint64Data: []uint64{1 << 40}}, []float64{1 << 40}},
+		{&onnx.TensorProto{DataType: int32(onnx.TensorProto_INT64), Int64Data: []int64{-1 << 40}}, []float64{-1 << 40}},
+	}
+	for _, test := range tests {
+		dtype := onnx.TensorProto_DataType(test.init.GetDataType())
+		raw, err := typedRawData(test.init)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", dtype, err)
+			continue
+		}
+		got, err := decodeRawData(dtype, raw)
+		if err != nil {
+			t.Errorf("%s: decoding encoded data: %v", dtype, err)
+			continue
+		}
+		if !sameValues(got, test.want) {
+			t.Errorf("%s: round tripped %v, want %v", dtype, got, test.want)
+		}
+	}
+}
+
+func TestTypedRawDataStrings(t *testing.T) {
+	raw, err := typedRawData(&onnx.TensorProto{
+		DataType:   int32(onnx.TensorProto_STRING),
+		StringData: [][]byte{[]byte("a")},
+	})
+	if err != nil || raw != nil {
+		t.Errorf("string tensors got raw %v and error %v, want neither", raw, err)
+	}
+	if _, err = typedRawData(&onnx.TensorProto{DataType: int32(onnx.TensorProto_UNDEFINED)}); err == nil {
+		t.Error("undefined dtype is accepted")
+	}
+}
+
+func TestBlobHasData(t *testing.T) {
+	tests := []struct {
+		name string
+		blob *storage.BlobStorage
+		want bool
+	}{
+		{"operator", &storage.BlobStorage{}, false},
+		{"raw data", &storage.BlobStorage{Dtype: int32(onnx.TensorProto_FLOAT), Shape: []uint64{1}, RawData: make([]byte, 4)}, true},
+		{"strings", &storage.BlobStorage{Dtype: int32(onnx.TensorProto_STRING), Shape: []uint64{1}, Strings: [][]byte{[]byte("a")}}, true},
+		{"zero dimension", &storage.BlobStorage{Dtype: int32(onnx.TensorProto_FLOAT), Shape: []uint64{0, 3}}, true},
+		{"scalar without data", &storage.BlobStorage{Dtype: int32(onnx.TensorProto_FLOAT)}, false},
+	}
+	for _, test := range tests {
+		if got := blobHasData(test.blob); got != test.want {
+			t.Errorf("%s: blobHasData = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
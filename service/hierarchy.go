@@ -0,0 +1,83 @@
+package service
+
+import (
+	"sort"
+
+	"github.com/mingkaic/accretion/proto/profile"
+)
+
+// scopeNodes lists the subgraph scopes of control-flow nodes then hides
+// the subgraphs of collapsed nodes, edges of hidden nodes are rerouted to
+// their outermost collapsed ancestor
+func scopeNodes(nodes []*profile.SigmaNode, edges []*profile.SigmaEdge,
+	collapse []string, collapseAll bool) ([]*profile.SigmaNode, []*profile.SigmaEdge) {
+	var (
+		byId   = make(map[string]*profile.SigmaNode, len(nodes))
+		scopes = make(map[string]map[string]struct{})
+	)
+	for _, node := range nodes {
+		byId[node.Id] = node
+		if node.Parent == "" {
+			continue
+		}
+		if _, ok := scopes[node.Parent]; !ok {
+			scopes[node.Parent] = make(map[string]struct{})
+		}
+		scopes[node.Parent][node.Scope] = struct{}{}
+	}
+	collapsed := make(map[string]struct{})
+	for _, id := range collapse {
+		collapsed[id] = struct{}{}
+	}
+	for id, nodeScopes := range scopes {
+		node, ok := byId[id]
+		if !ok {
+			continue
+		}
+		for scope := range nodeScopes {
+			node.Scopes = append(node.Scopes, scope)
+		}
+		sort.Strings(node.Scopes)
+		if _, ok := collapsed[id]; ok || collapseAll {
+			node.Collapsed = true
+		}
+	}
+
+	// visible is the outermost collapsed ancestor of id or id itself
+	visible := func(id string) string {
+		out := id
+		for node, ok := byId[id]; ok && node.Parent != ""; node, ok = byId[node.Parent] {
+			if parent, ok := byId[node.Parent]; ok && parent.Collapsed {
+				out = parent.Id
+			}
+		}
+		return out
+	}
+	var shown []*profile.SigmaNode
+	for _, node := range nodes {
+		if visible(node.Id) == node.Id {
+			shown = append(shown, node)
+		}
+	}
+	type edgeKey struct {
+		source, target, label string
+	}
+	var (
+		rerouted []*profile.SigmaEdge
+		seen     = make(map[edgeKey]struct{}, len(edges))
+	)
+	for _, edge := range edges {
+		source, target := visible(edge.Source), visible(edge.Target)
+		if source == target {
+			continue
+		}
+		key := edgeKey{source, target, edge.Label}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		edge.Source, edge.Target = source, target
+		rerouted = append(rerouted, edge)
+	}
+	return shown, rerouted
+}
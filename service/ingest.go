@@ -0,0 +1,281 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"strings"
+
+	"github.com/mingkaic/onnx_go/onnx"
+
+	"github.com/mingkaic/accretion/data"
+	"github.com/mingkaic/accretion/proto/profile"
+	"github.com/mingkaic/accretion/proto/storage"
+)
+
+type (
+	// ingestion is a profile's graph as it receives operator data
+	ingestion struct {
+		record  *data.TenncorProfile
+		graph   map[string]*data.TenncorNode
+		outputs []string
+		// staged nodes had their blobs written ahead and their data released
+		staged map[string]struct{}
+		// partial nodes await tensor parts by the raw bytes their tensors need
+		partial map[string]uint64
+	}
+)
+
+func newIngestion(record *data.TenncorProfile, model *onnx.ModelProto) (*ingestion, error) {
+	pbGraph := model.GetGraph()
+	graph, _, err := transformGraph(pbGraph, "", "")
+	if err != nil {
+		return nil, err
+	}
+	trainingOutputs, err := transformTraining(model.GetTrainingInfo(), graph)
+	if err != nil {
+		return nil, err
+	}
+	outputs := make([]string, 0, len(pbGraph.GetOutput())+len(trainingOutputs))
+	for _, output := range pbGraph.GetOutput() {
+		outputs = append(outputs, output.GetName())
+	}
+	// training graph results are bound to initializers rather than consumed
+	outputs = append(outputs, trainingOutputs...)
+	for _, node := range graph {
+		node.ProfileId = record.ProfileId
+	}
+	return &ingestion{
+		record:  record,
+		graph:   graph,
+		outputs: outputs,
+		staged:  make(map[string]struct{}),
+		partial: make(map[string]uint64),
+	}, nil
+}
+
+// addOperatorData sets runtimes and tensors of nodes in opData,
+// operator data of unknown nodes is ignored
+func (ing *ingestion) addOperatorData(opData map[string]*profile.FuncInfo) data.NodeErrors {
+	nodeErrs := make(data.NodeErrors)
+	for id, op := range opData {
+		node, ok := ing.graph[id]
+		if !ok {
+			continue
+		}
+		// operator data sent twice replaces the earlier runtime
+		ing.record.TotalRuntime += op.GetRuntime() - node.Runtime
+		node.Runtime = op.GetRuntime()
+		if denseData := op.GetDenseData(); denseData != nil {
+			if variable, err := transformVariable(denseData); err == nil {
+				node.Shape = variable.Shape
+				node.Dtype = variable.Dtype
+				node.Raw = variable.Raw
+				node.Strings = variable.Strings
+			} else {
+				nodeErrs[id] = fmt.Errorf("bad dense data: %v", err)
+			}
+		} else if sparseData := op.GetSparseData(); sparseData != nil {
+			if variable, err := transformSVariable(sparseData); err == nil {
+				node.Shape = variable.Shape
+				node.Dtype = variable.Dtype
+				node.Raw = variable.Raw
+				node.Strings = variable.Strings
+				node.Sinfo = variable.Sinfo
+			} else {
+				nodeErrs[id] = fmt.Errorf("bad sparse data: %v", err)
+			}
+		}
+	}
+	return nodeErrs
+}
+
+// stageOperatorData adds opData then writes the blobs of its nodes to store,
+// releasing their data so a stream holds one chunk of tensors at a time
+func (ing *ingestion) stageOperatorData(store data.Store, opData map[string]*profile.FuncInfo) data.NodeErrors {
+	nodeErrs := ing.addOperatorData(opData)
+	for id := range opData {
+		node, ok := ing.graph[id]
+		if !ok {
+			continue
+		}
+		delete(ing.partial, id)
+		if _, ok := nodeErrs[id]; ok {
+			continue
+		}
+		if len(node.Raw) == 0 && len(node.Strings) == 0 && node.Sinfo == nil {
+			// dense data without values may be followed by tensor parts
+			if need := rawBytes(node); need > 0 {
+				ing.partial[id] = need
+			}
+		}
+		if err := store.StageBlob(ing.record.ProfileId, id, nodeBlob(node)); err != nil {
+			nodeErrs[id] = fmt.Errorf("saving blob: %v", err)
+			continue
+		}
+		node.Raw = nil
+		node.Strings = nil
+		node.Sinfo = nil
+		ing.staged[id] = struct{}{}
+	}
+	return nodeErrs
+}
+
+// stageTensorPart appends part to the raw data of its node,
+// staging the node's blob again once its tensor is complete
+func (ing *ingestion) stageTensorPart(store data.Store, part *profile.TensorPart) data.NodeErrors {
+	id := part.GetNodeId()
+	need, ok := ing.partial[id]
+	if !ok {
+		return data.NodeErrors{id: errors.New("tensor part of a node without dense data awaiting raw data")}
+	}
+	node := ing.graph[id]
+	have := uint64(len(node.Raw))
+	if part.GetOffset() != have {
+		return data.NodeErrors{id: fmt.Errorf("tensor part at offset %d, expected offset %d", part.GetOffset(), have)}
+	}
+	raw := part.GetRawData()
+	if uint64(len(raw)) > need-have {
+		return data.NodeErrors{id: fmt.Errorf("tensor parts exceed the %d raw bytes of the tensor", need)}
+	}
+	node.Raw = append(node.Raw, raw...)
+	if uint64(len(node.Raw)) < need {
+		return nil
+	}
+	delete(ing.partial, id)
+	if err := store.StageBlob(ing.record.ProfileId, id, nodeBlob(node)); err != nil {
+		return data.NodeErrors{id: fmt.Errorf("saving blob: %v", err)}
+	}
+	node.Raw = nil
+	return nil
+}
+
+// incompleteTensors reports nodes that received some but not all of their tensor parts,
+// nodes receiving none keep the dense data they were sent
+func (ing *ingestion) incompleteTensors() data.NodeErrors {
+	nodeErrs := make(data.NodeErrors)
+	for id, need := range ing.partial {
+		if have := len(ing.graph[id].Raw); have > 0 {
+			nodeErrs[id] = fmt.Errorf("received %d of the %d raw bytes of the tensor", have, need)
+		}
+	}
+	return nodeErrs
+}
+
+// headerData reports initializers carrying data in a stream's header,
+// their tensors must be streamed as operator data instead
+func (ing *ingestion) headerData() data.NodeErrors {
+	nodeErrs := make(data.NodeErrors)
+	for id, node := range ing.graph {
+		if node.Kind != data.VariableKind && node.Kind != data.SparseVariableKind {
+			continue
+		}
+		if len(node.Raw) > 0 || len(node.Strings) > 0 || (node.Sinfo != nil && len(node.Sinfo.Indices) > 0) {
+			nodeErrs[id] = errors.New("initializer data in the stream header, stream it as operator data")
+		}
+	}
+	return nodeErrs
+}
+
+// link resolves node args and the roots to save
+func (ing *ingestion) link() ([]*data.TenncorNode, data.NodeErrors) {
+	// inputs name values which may differ from the names of their producers
+	producers := make(map[string]*data.TenncorNode)
+	for _, node := range ing.graph {
+		for _, output := range node.Outputs {
+			producers[output] = node
+		}
+	}
+	nodeErrs := make(data.NodeErrors)
+	for id, node := range ing.graph {
+		node.Args = make([]*data.TenncorNode, 0, len(node.Inputs))
+		var unresolved []string
+		for _, input := range node.Inputs {
+			if input == "" {
+				// omitted optional input
+				continue
+			}
+			if producer, ok := producers[input]; ok {
+				node.Args = append(node.Args, producer)
+			} else {
+				unresolved = append(unresolved, input)
+			}
+		}
+		var unbound []string
+		for _, value := range append(node.InitializedBy, node.UpdatedBy...) {
+			if _, ok := producers[value]; !ok {
+				unbound = append(unbound, value)
+			}
+		}
+		var problems []string
+		if len(unresolved) > 0 {
+			problems = append(problems, "unresolved inputs "+strings.Join(unresolved, ", "))
+		}
+		if len(unbound) > 0 {
+			problems = append(problems, "unresolved bindings "+strings.Join(unbound, ", "))
+		}
+		if len(problems) > 0 {
+			nodeErrs[id] = errors.New(strings.Join(problems, "; "))
+		}
+	}
+	roots := make([]*data.TenncorNode, 0, len(ing.outputs))
+	for _, name := range ing.outputs {
+		if producer, ok := producers[name]; ok {
+			roots = append(roots, producer)
+		} else {
+			nodeErrs[name] = fmt.Errorf("unresolved graph output %s", name)
+		}
+	}
+	for _, node := range ing.graph {
+		// subgraph results are yielded by their parent rather than consumed
+		if node.Parent != "" {
+			roots = append(roots, node)
+		}
+	}
+	return roots, nodeErrs
+}
+
+// blobs are the blobs of nodes saved from roots not yet staged
+func (ing *ingestion) blobs(roots []*data.TenncorNode) map[string]*storage.BlobStorage {
+	nodes := data.FlattenNodes(roots)
+	blobs := make(map[string]*storage.BlobStorage, len(nodes))
+	for _, node := range nodes {
+		if _, ok := ing.staged[node.Id]; !ok {
+			blobs[node.Id] = nodeBlob(node)
+		}
+	}
+	return blobs
+}
+
+// rawBytes is the size of node's tensor as raw data,
+// 0 if its type has no raw encoding or its size overflows
+func rawBytes(node *data.TenncorNode) uint64 {
+	size, ok := rawSizes[onnx.TensorProto_DataType(onnx.TensorProto_DataType_value[node.Dtype])]
+	if !ok {
+		return 0
+	}
+	n, err := shapeElements(node.Shape)
+	if err != nil {
+		return 0
+	}
+	hi, lo := bits.Mul64(n, uint64(size))
+	if hi != 0 {
+		return 0
+	}
+	return lo
+}
+
+func nodeBlob(node *data.TenncorNode) *storage.BlobStorage {
+	blob := &storage.BlobStorage{
+		Version: storage.BlobVersion_RAW_DATA,
+		RawData: node.Raw,
+		Strings: node.Strings,
+		Shape:   node.Shape,
+		Dtype:   onnx.TensorProto_DataType_value[node.Dtype],
+	}
+	if node.Sinfo != nil {
+		blob.Indices = node.Sinfo.Indices
+		blob.OuterIndices = node.Sinfo.OuterIndices
+	}
+	return blob
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mingkaic/onnx_go/onnx"
+
+	"github.com/mingkaic/accretion/data"
+	"github.com/mingkaic/accretion/proto/profile"
+	"github.com/mingkaic/accretion/proto/storage"
+)
+
+// stagingStore keeps the blobs staged to it, other methods are unimplemented
+type stagingStore struct {
+	data.Store
+	staged map[string]*storage.BlobStorage
+}
+
+func (store *stagingStore) StageBlob(profileId, id string, blob *storage.BlobStorage) error {
+	store.staged[id] = blob
+	return nil
+}
+
+// streamedModel has initializer a of 4 floats without data, consumed by neg
+func streamedModel(raw []byte) *onnx.ModelProto {
+	return &onnx.ModelProto{Graph: &onnx.GraphProto{
+		Initializer: []*onnx.TensorProto{{Name: "a", DataType: int32(onnx.TensorProto_FLOAT), Dims: []int64{4}, RawData: raw}},
+		Node:        []*onnx.NodeProto{{Name: "neg", OpType: "Neg", Input: []string{"a"}, Output: []string{"c"}}},
+		Output:      []*onnx.ValueInfoProto{{Name: "c"}},
+	}}
+}
+
+func TestStageTensorParts(t *testing.T) {
+	tests := []struct {
+		name    string
+		parts   []*profile.TensorPart
+		wantErr string
+	}{
+		{"no parts", nil, ""},
+		{"one part", []*profile.TensorPart{{NodeId: "a", RawData: []byte("0123456789abcdef")}}, ""},
+		{"split parts", []*profile.TensorPart{
+			{NodeId: "a", RawData: []byte("0123456")},
+			{NodeId: "a", Offset: 7, RawData: []byte("789abcdef")},
+		}, ""},
+		{"incomplete", []*profile.TensorPart{{NodeId: "a", RawData: []byte("01234567")}}, "received 8 of the 16 raw bytes"},
+		{"skipped bytes", []*profile.TensorPart{{NodeId: "a", Offset: 4, RawData: []byte("4567")}}, "expected offset 0"},
+		{"too many bytes", []*profile.TensorPart{{NodeId: "a", RawData: []byte("0123456789abcdefg")}}, "exceed the 16 raw bytes"},
+		{"after completion", []*profile.TensorPart{
+			{NodeId: "a", RawData: []byte("0123456789abcdef")},
+			{NodeId: "a", Offset: 16, RawData: []byte("g")},
+		}, "without dense data awaiting raw data"},
+		{"operator without data", []*profile.TensorPart{{NodeId: "neg", RawData: []byte("0")}}, "without dense data awaiting raw data"},
+	}
+	for _, test := range tests {
+		store := &stagingStore{staged: make(map[string]*storage.BlobStorage)}
+		ing, err := newIngestion(&data.TenncorProfile{ProfileId: "p"}, streamedModel(nil))
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.name, err)
+		}
+		nodeErrs := ing.stageOperatorData(store, map[string]*profile.FuncInfo{
+			"a": {Data: &profile.FuncInfo_DenseData{DenseData: streamedModel(nil).GetGraph().GetInitializer()[0]}},
+		})
+		for _, part := range test.parts {
+			if len(nodeErrs) > 0 {
+				break
+			}
+			nodeErrs = ing.stageTensorPart(store, part)
+		}
+		if len(nodeErrs) == 0 {
+			nodeErrs = ing.incompleteTensors()
+		}
+		if test.wantErr != "" {
+			if nodeErrs == nil || !strings.Contains(nodeErrs.Error(), test.wantErr) {
+				t.Errorf("%s: got errors %v, want %q", test.name, nodeErrs, test.wantErr)
+			}
+			continue
+		}
+		if len(nodeErrs) > 0 {
+			t.Errorf("%s: unexpected errors: %v", test.name, nodeErrs)
+			continue
+		}
+		var want []byte
+		for _, part := range test.parts {
+			want = append(want, part.GetRawData()...)
+		}
+		if got := store.staged["a"].GetRawData(); string(got) != string(want) {
+			t.Errorf("%s: staged %q, want %q", test.name, got, want)
+		}
+	}
+}
+
+func TestHeaderData(t *testing.T) {
+	for _, raw := range [][]byte{nil, make([]byte, 16)} {
+		ing, err := newIngestion(&data.TenncorProfile{ProfileId: "p"}, streamedModel(raw))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if nodeErrs := ing.headerData(); (len(nodeErrs) > 0) != (len(raw) > 0) {
+			t.Errorf("header initializer with %d bytes got errors %v", len(raw), nodeErrs)
+		}
+	}
+}
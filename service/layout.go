@@ -0,0 +1,271 @@
+package service
+
+import (
+	"math"
+	"sort"
+
+	"github.com/mingkaic/accretion/proto/profile"
+)
+
+const (
+	layoutSpacing   = 100
+	orderingSweeps  = 4
+	forceIterations = 200
+	// caps pairwise repulsions computed over all iterations,
+	// graphs with more pairs than this keep their layered positions
+	forceBudget = 50000000
+
+	defaultNodeSize = 5
+	minNodeSize     = 1
+	maxNodeSize     = 10
+)
+
+type (
+	// layoutGraph indexes sigma nodes with data flowing from producers to consumers
+	layoutGraph struct {
+		nodes     []*profile.SigmaNode
+		producers [][]int
+		consumers [][]int
+	}
+)
+
+func newLayoutGraph(nodes []*profile.SigmaNode, edges []*profile.SigmaEdge) *layoutGraph {
+	index := make(map[string]int, len(nodes))
+	for i, node := range nodes {
+		index[node.Id] = i
+	}
+	g := &layoutGraph{
+		nodes:     nodes,
+		producers: make([][]int, len(nodes)),
+		consumers: make([][]int, len(nodes)),
+	}
+	for _, edge := range edges {
+		// binding edges point weights at the ops updating them,
+		// laying them out as data flow would make a cycle of every trained weight
+		if edge.Kind != profile.EdgeKind_DATA {
+			continue
+		}
+		// edges point from consumer source to producer target
+		consumer, ok := index[edge.Source]
+		if !ok {
+			continue
+		}
+		producer, ok := index[edge.Target]
+		if !ok {
+			continue
+		}
+		g.producers[consumer] = append(g.producers[consumer], producer)
+		g.consumers[producer] = append(g.consumers[producer], consumer)
+	}
+	return g
+}
+
+// layoutNodes positions nodes in place according to layout and
+// sizes them by runtime
+func layoutNodes(nodes []*profile.SigmaNode, edges []*profile.SigmaEdge, layout profile.Layout) {
+	g := newLayoutGraph(nodes, edges)
+	switch layout {
+	case profile.Layout_FORCE:
+		g.force()
+	default:
+		g.layered()
+	}
+	sizeNodes(nodes)
+}
+
+// layered places nodes in a Sugiyama-style layering where each node sits one
+// layer below its deepest producer, then orders layers by barycenters
+func (g *layoutGraph) layered() {
+	if len(g.nodes) == 0 {
+		return
+	}
+	layers := g.layers()
+	pos := make([]float64, len(g.nodes))
+	for _, layer := range layers {
+		for i, n := range layer {
+			pos[n] = float64(i)
+		}
+	}
+	for sweep := 0; sweep < orderingSweeps; sweep++ {
+		// alternate sweeping down by producers and up by consumers
+		if sweep%2 == 0 {
+			for _, layer := range layers[1:] {
+				orderByBarycenter(layer, g.producers, pos)
+			}
+		} else {
+			for l := len(layers) - 2; l >= 0; l-- {
+				orderByBarycenter(layers[l], g.consumers, pos)
+			}
+		}
+	}
+	for l, layer := range layers {
+		offset := float64(len(layer)-1) / 2
+		for i, n := range layer {
+			g.nodes[n].X = int64((float64(i) - offset) * layoutSpacing)
+			g.nodes[n].Y = int64(l * layoutSpacing)
+		}
+	}
+}
+
+// layers assigns longest-path layers from the inputs by topological order,
+// nodes caught in cycles go after every other layer
+func (g *layoutGraph) layers() [][]int {
+	var (
+		n        = len(g.nodes)
+		indegree = make([]int, n)
+		layerOf  = make([]int, n)
+		queue    []int
+		visited  int
+		nlayers  int
+	)
+	for i := range g.nodes {
+		indegree[i] = len(g.producers[i])
+		if indegree[i] == 0 {
+			queue = append(queue, i)
+		}
+	}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		visited++
+		if layerOf[node]+1 > nlayers {
+			nlayers = layerOf[node] + 1
+		}
+		for _, consumer := range g.consumers[node] {
+			if layerOf[node]+1 > layerOf[consumer] {
+				layerOf[consumer] = layerOf[node] + 1
+			}
+			indegree[consumer]--
+			if indegree[consumer] == 0 {
+				queue = append(queue, consumer)
+			}
+		}
+	}
+	if visited < n {
+		for i := range g.nodes {
+			if indegree[i] > 0 {
+				layerOf[i] = nlayers
+			}
+		}
+		nlayers++
+	}
+	layers := make([][]int, nlayers)
+	for i := range g.nodes {
+		layers[layerOf[i]] = append(layers[layerOf[i]], i)
+	}
+	return layers
+}
+
+func orderByBarycenter(layer []int, neighbors [][]int, pos []float64) {
+	centers := make(map[int]float64, len(layer))
+	for _, n := range layer {
+		if len(neighbors[n]) == 0 {
+			centers[n] = pos[n]
+			continue
+		}
+		var sum float64
+		for _, neighbor := range neighbors[n] {
+			sum += pos[neighbor]
+		}
+		centers[n] = sum / float64(len(neighbors[n]))
+	}
+	sort.SliceStable(layer, func(i, j int) bool {
+		return centers[layer[i]] < centers[layer[j]]
+	})
+	for i, n := range layer {
+		pos[n] = float64(i)
+	}
+}
+
+// force places nodes by Fruchterman-Reingold starting from the
+// layered positions so the result is deterministic, running
+// as many iterations as forceBudget allows
+func (g *layoutGraph) force() {
+	g.layered()
+	var (
+		n          = len(g.nodes)
+		xs         = make([]float64, n)
+		ys         = make([]float64, n)
+		dx         = make([]float64, n)
+		dy         = make([]float64, n)
+		k          = float64(layoutSpacing)
+		startTemp  = k * math.Sqrt(float64(n))
+		iterations = forceIterations
+	)
+	pairs := n * (n - 1) / 2
+	if pairs > forceBudget {
+		return
+	}
+	if pairs > 0 && pairs*iterations > forceBudget {
+		iterations = forceBudget / pairs
+	}
+	for i, node := range g.nodes {
+		xs[i] = float64(node.X)
+		ys[i] = float64(node.Y)
+	}
+	for iter := 0; iter < iterations; iter++ {
+		temp := startTemp * (1 - float64(iter)/float64(iterations))
+		for i := range dx {
+			dx[i], dy[i] = 0, 0
+		}
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				fx, fy, dist := displacement(xs[i], ys[i], xs[j], ys[j])
+				repulse := k * k / dist
+				dx[i] += fx / dist * repulse
+				dy[i] += fy / dist * repulse
+				dx[j] -= fx / dist * repulse
+				dy[j] -= fy / dist * repulse
+			}
+		}
+		for consumer, producers := range g.producers {
+			for _, producer := range producers {
+				fx, fy, dist := displacement(xs[consumer], ys[consumer], xs[producer], ys[producer])
+				attract := dist * dist / k
+				dx[consumer] -= fx / dist * attract
+				dy[consumer] -= fy / dist * attract
+				dx[producer] += fx / dist * attract
+				dy[producer] += fy / dist * attract
+			}
+		}
+		for i := 0; i < n; i++ {
+			dist := math.Max(math.Hypot(dx[i], dy[i]), 1e-9)
+			step := math.Min(dist, temp)
+			xs[i] += dx[i] / dist * step
+			ys[i] += dy[i] / dist * step
+		}
+	}
+	for i, node := range g.nodes {
+		node.X = int64(xs[i])
+		node.Y = int64(ys[i])
+	}
+}
+
+// displacement returns the vector from b to a and its length,
+// nudging coincident points apart
+func displacement(ax, ay, bx, by float64) (float64, float64, float64) {
+	fx, fy := ax-bx, ay-by
+	dist := math.Hypot(fx, fy)
+	if dist < 1e-3 {
+		return 1e-3, 0, 1e-3
+	}
+	return fx, fy, dist
+}
+
+// sizeNodes scales node sizes linearly with runtime
+func sizeNodes(nodes []*profile.SigmaNode) {
+	var maxRuntime uint64
+	for _, node := range nodes {
+		if node.Runtime > maxRuntime {
+			maxRuntime = node.Runtime
+		}
+	}
+	for _, node := range nodes {
+		if maxRuntime == 0 {
+			node.Size = defaultNodeSize
+			continue
+		}
+		ratio := float64(node.Runtime) / float64(maxRuntime)
+		node.Size = minNodeSize + int64(math.Round(ratio*(maxNodeSize-minNodeSize)))
+	}
+}
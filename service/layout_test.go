@@ -0,0 +1,33 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/mingkaic/accretion/proto/profile"
+)
+
+func TestLayeredTrainedWeight(t *testing.T) {
+	var (
+		x   = &profile.SigmaNode{Id: "x"}
+		w   = &profile.SigmaNode{Id: "w"}
+		mul = &profile.SigmaNode{Id: "mul"}
+		sgd = &profile.SigmaNode{Id: "sgd"}
+	)
+	edges := []*profile.SigmaEdge{
+		{Id: "0", Source: "mul", Target: "x"},
+		{Id: "1", Source: "mul", Target: "w"},
+		{Id: "2", Source: "sgd", Target: "w"},
+		{Id: "3", Source: "w", Target: "sgd", Kind: profile.EdgeKind_UPDATE},
+		{Id: "4", Source: "w", Target: "sgd", Kind: profile.EdgeKind_INITIALIZATION},
+	}
+	layoutNodes([]*profile.SigmaNode{x, w, mul, sgd}, edges, profile.Layout_LAYERED)
+	if w.Y >= mul.Y {
+		t.Errorf("weight at y=%d is not above its consumer at y=%d", w.Y, mul.Y)
+	}
+	if w.Y >= sgd.Y {
+		t.Errorf("weight at y=%d is not above its update at y=%d", w.Y, sgd.Y)
+	}
+	if x.Y != w.Y {
+		t.Errorf("weight at y=%d is not layered with input at y=%d", w.Y, x.Y)
+	}
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/mingkaic/onnx_go/onnx"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/mingkaic/accretion/data"
+	"github.com/mingkaic/accretion/proto/profile"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 1000
+)
+
+var (
+	profileOrders = map[profile.ProfileOrder]string{
+		profile.ProfileOrder_CREATED_AT:    data.OrderCreatedAt,
+		profile.ProfileOrder_NODE_COUNT:    data.OrderNodeCount,
+		profile.ProfileOrder_TOTAL_RUNTIME: data.OrderTotalRuntime,
+	}
+)
+
+// newProfileRecord describes the requested profile by its model metadata,
+// totals over nodes are left to ingestion
+func newProfileRecord(profileId string, req *profile.CreateProfileRequest) *data.TenncorProfile {
+	model := req.GetModel()
+	record := data.NewTenncorProfile(profileId)
+	record.Name = req.GetName()
+	record.Tags = req.GetTags()
+	record.Pinned = req.GetPinned()
+	record.Model = model.GetGraph().GetName()
+	record.ProducerName = model.GetProducerName()
+	record.ProducerVersion = model.GetProducerVersion()
+	record.ModelVersion = model.GetModelVersion()
+	record.ModelDomain = model.GetDomain()
+	record.DocString = model.GetDocString()
+	if props := model.GetMetadataProps(); len(props) > 0 {
+		record.MetadataProps = make(data.Properties, len(props))
+		for _, prop := range props {
+			record.MetadataProps[prop.GetKey()] = prop.GetValue()
+		}
+	}
+	if opsets := model.GetOpsetImport(); len(opsets) > 0 {
+		record.OpsetImport = make(data.Properties, len(opsets))
+		for _, opset := range opsets {
+			record.OpsetImport[opset.GetDomain()] = strconv.FormatInt(opset.GetVersion(), 10)
+		}
+	}
+	return record
+}
+
+func profileSummary(record *data.TenncorProfile) *profile.ProfileSummary {
+	summary := &profile.ProfileSummary{
+		ProfileId:       record.ProfileId,
+		Name:            record.Name,
+		Tags:            record.Tags,
+		Status:          record.Status,
+		StatusError:     record.Error,
+		ModelName:       record.Model,
+		ProducerName:    record.ProducerName,
+		ProducerVersion: record.ProducerVersion,
+		ModelVersion:    record.ModelVersion,
+		Domain:          record.ModelDomain,
+		DocString:       record.DocString,
+		MetadataProps:   record.MetadataProps,
+		TotalRuntime:    record.TotalRuntime,
+		NodeCount:       uint64(record.NodeCount),
+		BlobBytes:       uint64(record.BlobBytes),
+		Pinned:          record.Pinned,
+	}
+	if !record.CreatedAt.IsZero() {
+		summary.CreatedAt = timestamppb.New(record.CreatedAt)
+	}
+	sort.Strings(summary.Tags)
+	domains := make([]string, 0, len(record.OpsetImport))
+	for domain := range record.OpsetImport {
+		domains = append(domains, domain)
+	}
+	sort.Strings(domains)
+	for _, domain := range domains {
+		version, err := strconv.ParseInt(record.OpsetImport[domain], 10, 64)
+		if err != nil {
+			log.Errorf("profile %s has bad opset version %s for domain %s",
+				record.ProfileId, record.OpsetImport[domain], domain)
+			continue
+		}
+		summary.OpsetImport = append(summary.OpsetImport, &onnx.OperatorSetIdProto{
+			Domain:  domain,
+			Version: version,
+		})
+	}
+	return summary
+}
+
+// profileQuery translates req into a store query for one page of profiles
+func profileQuery(req *profile.ListProfileRequest) (*data.ProfileQuery, error) {
+	pageSize := int(req.GetPageSize())
+	switch {
+	case pageSize < 0:
+		return nil, fmt.Errorf("negative page size %d", pageSize)
+	case pageSize == 0:
+		pageSize = defaultPageSize
+	case pageSize > maxPageSize:
+		pageSize = maxPageSize
+	}
+	offset, err := decodePageToken(req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	orderBy, ok := profileOrders[req.GetOrderBy()]
+	if !ok {
+		return nil, fmt.Errorf("unknown profile order %v", req.GetOrderBy())
+	}
+	query := &data.ProfileQuery{
+		Tags:         req.GetTags(),
+		NameContains: req.GetNameContains(),
+		ProducerName: req.GetProducerName(),
+		OrderBy:      orderBy,
+		Ascending:    req.GetAscending(),
+		Offset:       offset,
+		Limit:        pageSize,
+	}
+	if after := req.GetCreatedAfter(); after != nil {
+		if err = after.CheckValid(); err != nil {
+			return nil, err
+		}
+		query.CreatedAfter = after.AsTime()
+	}
+	if before := req.GetCreatedBefore(); before != nil {
+		if err = before.CheckValid(); err != nil {
+			return nil, err
+		}
+		query.CreatedBefore = before.AsTime()
+	}
+	return query, nil
+}
+
+// page tokens are opaque offsets into the listing
+func encodePageToken(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+func decodePageToken(token string) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, fmt.Errorf("bad page token %s", token)
+	}
+	offset, err := strconv.Atoi(string(b))
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("bad page token %s", token)
+	}
+	return offset, nil
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/mingkaic/accretion/data"
+)
+
+type (
+	// RetentionPolicy bounds which profiles are kept, zero values are unbounded
+	RetentionPolicy struct {
+		MaxAge              time.Duration
+		MaxProfilesPerModel int
+		MaxBlobBytes        int64
+	}
+
+	// Collector deletes the oldest unpinned profiles violating a retention policy
+	Collector interface {
+		// Run collects every interval until stop closes
+		Run(interval time.Duration, stop <-chan struct{})
+		Collect() error
+	}
+
+	collector struct {
+		store  data.Store
+		policy RetentionPolicy
+	}
+)
+
+func NewCollector(store data.Store, policy RetentionPolicy) Collector {
+	return &collector{store: store, policy: policy}
+}
+
+func (p RetentionPolicy) Enabled() bool {
+	return p.MaxAge > 0 || p.MaxProfilesPerModel > 0 || p.MaxBlobBytes > 0
+}
+
+func (c *collector) Run(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := c.Collect(); err != nil {
+			log.Errorf("profile collection failed: %v", err)
+		}
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *collector) Collect() error {
+	profiles, err := c.store.ListProfileRecords(nil)
+	if err != nil {
+		return err
+	}
+	for _, profileId := range c.expired(profiles, time.Now()) {
+		deleted, err := c.store.DeleteProfile(profileId)
+		if err != nil {
+			return err
+		}
+		log.Infof("collected profile %s: %d nodes, %d blobs, %d bytes",
+			profileId, deleted.Nodes, deleted.Blobs, deleted.BlobBytes)
+	}
+	return nil
+}
+
+// expired lists profiles to delete oldest first, pinned profiles count
+// towards model and byte limits but are never listed
+func (c *collector) expired(profiles []*data.TenncorProfile, now time.Time) []string {
+	sort.SliceStable(profiles, func(i, j int) bool {
+		return profiles[i].CreatedAt.Before(profiles[j].CreatedAt)
+	})
+	var (
+		out        []string
+		perModel   = make(map[string]int)
+		totalBytes int64
+	)
+	for _, profile := range profiles {
+		perModel[profile.Model]++
+		totalBytes += profile.BlobBytes
+	}
+	for _, profile := range profiles {
+		if profile.Pinned {
+			continue
+		}
+		switch {
+		case c.policy.MaxAge > 0 && now.Sub(profile.CreatedAt) > c.policy.MaxAge:
+		case c.policy.MaxProfilesPerModel > 0 && perModel[profile.Model] > c.policy.MaxProfilesPerModel:
+		case c.policy.MaxBlobBytes > 0 && totalBytes > c.policy.MaxBlobBytes:
+		default:
+			continue
+		}
+		perModel[profile.Model]--
+		totalBytes -= profile.BlobBytes
+		out = append(out, profile.ProfileId)
+	}
+	return out
+}
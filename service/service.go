@@ -1,218 +1,476 @@
 package service
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
 	"github.com/google/uuid"
-	"math"
-	"sync"
 
 	"github.com/mingkaic/accretion/proto/profile"
 	"github.com/mingkaic/onnx_go/onnx"
 	log "github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/mingkaic/accretion/data"
-	"github.com/mingkaic/accretion/proto/storage"
 )
 
 type (
 	GraphService interface {
-		ListGraphProfiles() ([]string, error)
-		GetGraphProfile(string) ([]*profile.SigmaNode, []*profile.SigmaEdge, error)
-		CreateGraphProfile(string, *onnx.ModelProto, map[string]*profile.FuncInfo) error
+		ListGraphProfiles(*profile.ListProfileRequest) (*profile.ListProfileResponse, error)
+		GetGraphProfile(*profile.GetProfileRequest) (*profile.GetProfileResponse, error)
+		CreateGraphProfile(string, *profile.CreateProfileRequest) error
+		StreamGraphProfile(string, ProfileChunks) error
+		DeleteGraphProfile(string) (*profile.DeleteProfileResponse, error)
+		PinGraphProfile(string, bool) error
+		GetTensorData(*profile.GetTensorDataRequest) (*profile.GetTensorDataResponse, error)
 	}
 
-	graphService struct{}
-
-	ProfileGroupbyEntry struct {
-		ProfileId string `json:"profile_id"`
-		Count     int    `json:"count"`
+	// ProfileChunks receives a streamed profile's header then its operator data
+	ProfileChunks interface {
+		Recv() (*profile.StreamCreateProfileRequest, error)
 	}
 
-	ProfileGroupby struct {
-		GroupBy []*ProfileGroupbyEntry `json:"@groupby"`
+	graphService struct {
+		store data.Store
 	}
 
-	ProfileNode struct {
-		Id, Label string
-		Arg       []struct {
-			Id string
-		}
+	subgraph struct {
+		scope string
+		graph *onnx.GraphProto
 	}
 )
 
 const (
-	batchsize     = 8
-	profileLookup = `{
-	profiles(func:has(profile_id)) @groupby(profile_id) {
-		count(uid)
-	}
-}`
-	nodesLookupFmt = `{
-	nodes(func: allofterms(profile_id, "%s")) {
-		id
-		label
-		arg {
-			id
-		}
+	errorDomain       = "accretion"
+	nodeFailureReason = "NODE_INGESTION_FAILED"
+)
+
+var (
+	nodeKinds = map[string]profile.NodeKind{
+		data.PlaceholderKind:    profile.NodeKind_PLACEHOLDER,
+		data.VariableKind:       profile.NodeKind_VARIABLE,
+		data.SparseVariableKind: profile.NodeKind_SPARSE_VARIABLE,
+		data.FunctionKind:       profile.NodeKind_FUNCTION,
 	}
-}`
 )
 
-func NewGraphService() GraphService {
-	return &graphService{}
+func NewGraphService(store data.Store) GraphService {
+	return &graphService{store: store}
 }
 
-func (graphService) ListGraphProfiles() ([]string, error) {
-	var profiles []string
-	if err := data.WithTx(func(tx *data.Txn) (err error) {
-		var (
-			b        []byte
-			response = make(map[string][]*ProfileGroupby)
-		)
-		b, err = data.QueryNode(tx, profileLookup)
-		if err != nil {
-			return
+// ListGraphProfiles summarizes a page of profiles matching req
+func (svc *graphService) ListGraphProfiles(req *profile.ListProfileRequest) (*profile.ListProfileResponse, error) {
+	query, err := profileQuery(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	pageSize := query.Limit
+	// look one past the page to tell whether another follows
+	query.Limit++
+	records, err := svc.store.ListProfileRecords(query)
+	if err != nil {
+		return nil, err
+	}
+	out := &profile.ListProfileResponse{}
+	if len(records) > pageSize {
+		records = records[:pageSize]
+		out.NextPageToken = encodePageToken(query.Offset + pageSize)
+	}
+	out.Profiles = make([]*profile.ProfileSummary, len(records))
+	for i, record := range records {
+		out.Profiles[i] = profileSummary(record)
+	}
+	return out, nil
+}
+
+func (svc *graphService) GetGraphProfile(req *profile.GetProfileRequest) (*profile.GetProfileResponse, error) {
+	id := req.GetProfileId()
+	if err := checkProfileId(id); err != nil {
+		return nil, err
+	}
+	// profiles created before records were kept have no record
+	record, err := svc.store.GetProfile(id)
+	if err != nil && !errors.Is(err, data.ErrNotFound) {
+		return nil, err
+	}
+	if record != nil && record.Status == data.ProfileFailed {
+		return nil, status.Error(codes.FailedPrecondition, record.Error)
+	}
+	profNodes, err := svc.store.GetProfileNodes(id)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil && len(profNodes) == 0 {
+		return nil, status.Errorf(codes.NotFound, "profile %s not found", id)
+	}
+	var (
+		nodes     = make([]*profile.SigmaNode, len(profNodes))
+		edges     []*profile.SigmaEdge
+		producers = make(map[string]string)
+	)
+	types := make(map[string]*data.ValueType)
+	for _, profNode := range profNodes {
+		for _, output := range profNode.Outputs {
+			producers[output] = profNode.Id
 		}
-		if err = json.Unmarshal(b, &response); err != nil {
-			return
+		for _, t := range profNode.OutputTypes {
+			types[t.Name] = t
 		}
-		entries, ok := response["profiles"]
-		if !ok || len(entries) < 1 {
-			err = fmt.Errorf("invalid response from dgraph: %s", string(b))
-			return
+	}
+	for i, profNode := range profNodes {
+		nodes[i] = &profile.SigmaNode{
+			Id:       profNode.Id,
+			Label:    profNode.Label,
+			Runtime:  profNode.Runtime,
+			Shape:    profNode.Shape,
+			Dtype:    profNode.Dtype,
+			OpDomain: profNode.Domain,
+			Kind:     nodeKinds[profNode.Kind],
+			Outputs:  sigmaValueTypes(profNode.OutputTypes),
+			Parent:   profNode.Parent,
+			Scope:    profNode.Scope,
+			Section:  nodeSection(profNode),
 		}
-		profiles = make([]string, len(entries[0].GroupBy))
-		for i, profile := range entries[0].GroupBy {
-			profiles[i] = profile.ProfileId
+		if len(profNode.Annotations) > 0 {
+			nodes[i].Annotations = make(map[string]string, len(profNode.Annotations))
+			for _, annotation := range profNode.Annotations {
+				nodes[i].Annotations[annotation.Key] = annotation.Value
+			}
 		}
-		return
-	}); err != nil {
-		return nil, err
+		edges = append(edges, nodeEdges(profNode, producers, types)...)
+		edges = append(edges, bindingEdges(profNode, producers)...)
 	}
-	return profiles, nil
+	nodes, edges = scopeNodes(nodes, edges, req.GetCollapse(), req.GetCollapseAll())
+	layoutNodes(nodes, edges, req.GetLayout())
+	return &profile.GetProfileResponse{
+		Nodes:    nodes,
+		Edges:    edges,
+		Sections: profileSections(profNodes),
+	}, nil
 }
 
-func (graphService) GetGraphProfile(id string) ([]*profile.SigmaNode, []*profile.SigmaEdge, error) {
-	var (
-		nodes []*profile.SigmaNode
-		edges []*profile.SigmaEdge
-	)
-	if err := data.WithTx(func(tx *data.Txn) (err error) {
-		var (
-			b        []byte
-			response = make(map[string][]*ProfileNode)
-		)
-		b, err = data.QueryNode(tx, fmt.Sprintf(nodesLookupFmt, id))
+// nodeEdges points node to the producer of each value it consumes,
+// labelled by value name and type, nodes saved without inputs point to their args
+func nodeEdges(node *data.TenncorNode, producers map[string]string,
+	types map[string]*data.ValueType) []*profile.SigmaEdge {
+	var edges []*profile.SigmaEdge
+	if len(node.Inputs) == 0 {
+		for _, arg := range node.Args {
+			edges = append(edges, &profile.SigmaEdge{
+				Id:     uuid.NewString(),
+				Source: node.Id,
+				Target: arg.Id,
+			})
+		}
+		return edges
+	}
+	seen := make(map[string]struct{}, len(node.Inputs))
+	for _, input := range node.Inputs {
+		producer, ok := producers[input]
+		if !ok {
+			continue
+		}
+		if _, ok := seen[input]; ok {
+			continue
+		}
+		seen[input] = struct{}{}
+		edge := &profile.SigmaEdge{
+			Id:     uuid.NewString(),
+			Source: node.Id,
+			Target: producer,
+			Label:  input,
+		}
+		if t, ok := types[input]; ok {
+			edge.Dtype = t.Dtype
+			edge.Dims = t.Dims
+		}
+		edges = append(edges, edge)
+	}
+	return edges
+}
+
+// CreateGraphProfile saves the requested model and its operator data under profileId,
+// on failure the profile is marked failed and the returned status
+// details every node that failed
+func (svc *graphService) CreateGraphProfile(profileId string, req *profile.CreateProfileRequest) error {
+	record := newProfileRecord(profileId, req)
+	err := svc.createGraphProfile(record, req)
+	if err != nil {
+		svc.failProfile(record, err)
+	}
+	return err
+}
+
+func (svc *graphService) createGraphProfile(record *data.TenncorProfile, req *profile.CreateProfileRequest) error {
+	ing, err := newIngestion(record, req.GetModel())
+	if err != nil {
+		return ingestionStatus(codes.InvalidArgument, record.ProfileId, err)
+	}
+	return svc.saveIngestion(ing, ing.addOperatorData(req.GetOperatorData()))
+}
+
+// StreamGraphProfile saves a model received from chunks under profileId,
+// writing operator data as each chunk arrives, failures are handled
+// as in CreateGraphProfile, the header's initializers must not carry data
+func (svc *graphService) StreamGraphProfile(profileId string, chunks ProfileChunks) error {
+	first, err := chunks.Recv()
+	if err == io.EOF {
+		return status.Error(codes.InvalidArgument, "profile stream has no header")
+	}
+	if err != nil {
+		return err
+	}
+	header := first.GetHeader()
+	if header == nil {
+		return status.Error(codes.InvalidArgument, "profile stream must start with its header")
+	}
+	record := newProfileRecord(profileId, header)
+	if err = svc.streamGraphProfile(record, header, chunks); err != nil {
+		svc.failProfile(record, err)
+	}
+	return err
+}
+
+func (svc *graphService) streamGraphProfile(record *data.TenncorProfile,
+	header *profile.CreateProfileRequest, chunks ProfileChunks) error {
+	profileId := record.ProfileId
+	ing, err := newIngestion(record, header.GetModel())
+	if err != nil {
+		return ingestionStatus(codes.InvalidArgument, profileId, err)
+	}
+	if nodeErrs := ing.headerData(); len(nodeErrs) > 0 {
+		return ingestionStatus(codes.InvalidArgument, profileId, nodeErrs)
+	}
+	if nodeErrs := ing.stageOperatorData(svc.store, header.GetOperatorData()); len(nodeErrs) > 0 {
+		return ingestionStatus(codes.InvalidArgument, profileId, nodeErrs)
+	}
+	for {
+		chunk, err := chunks.Recv()
+		if err == io.EOF {
+			break
+		}
 		if err != nil {
-			return
+			return err
 		}
-		if err = json.Unmarshal(b, &response); err != nil {
-			return
+		if chunk.GetHeader() != nil {
+			return status.Errorf(codes.InvalidArgument, "profile %s received a second header", profileId)
 		}
-		profNodes, ok := response["nodes"]
-		if !ok {
-			err = fmt.Errorf("invalid response from dgraph: %s", string(b))
-			return
-		}
-		nodes = make([]*profile.SigmaNode, len(profNodes))
-		row := int(math.Sqrt(float64(len(nodes))))
-		for i, profNode := range profNodes {
-			nodes[i] = &profile.SigmaNode{
-				Id:    profNode.Id,
-				Label: profNode.Label,
-				X:     int64(i % row),
-				Y:     int64(i / row),
-				Size:  5,
-			}
-			if len(profNode.Arg) > 0 {
-				for _, arg := range profNode.Arg {
-					id := uuid.NewString()
-					edges = append(edges, &profile.SigmaEdge{
-						Id:     id,
-						Source: profNode.Id,
-						Target: arg.Id,
-					})
-				}
+		var nodeErrs data.NodeErrors
+		if part := chunk.GetTensorPart(); part != nil {
+			nodeErrs = ing.stageTensorPart(svc.store, part)
+		} else {
+			nodeErrs = ing.stageOperatorData(svc.store, chunk.GetOperatorData().GetOperatorData())
+		}
+		if len(nodeErrs) > 0 {
+			return ingestionStatus(codes.InvalidArgument, profileId, nodeErrs)
+		}
+	}
+	return svc.saveIngestion(ing, ing.incompleteTensors())
+}
+
+// saveIngestion links and saves the ingested graph unless it or nodeErrs has errors
+func (svc *graphService) saveIngestion(ing *ingestion, nodeErrs data.NodeErrors) error {
+	profileId := ing.record.ProfileId
+	roots, linkErrs := ing.link()
+	for id, err := range linkErrs {
+		if _, ok := nodeErrs[id]; !ok {
+			if nodeErrs == nil {
+				nodeErrs = make(data.NodeErrors)
 			}
+			nodeErrs[id] = err
+		}
+	}
+	if len(nodeErrs) > 0 {
+		return ingestionStatus(codes.InvalidArgument, profileId, nodeErrs)
+	}
+
+	log.Debug("saving profile nodes and blobs")
+	if err := svc.store.CreateProfile(ing.record, roots, ing.blobs(roots)); err != nil {
+		return ingestionStatus(codes.Internal, profileId, err)
+	}
+	return nil
+}
+
+// failProfile marks record failed because of err
+func (svc *graphService) failProfile(record *data.TenncorProfile, err error) {
+	if ferr := svc.store.FailProfile(record, errors.New(status.Convert(err).Message())); ferr != nil {
+		log.Errorf("failed to mark profile %s as failed: %v", record.ProfileId, ferr)
+	}
+}
+
+// ingestionStatus describes err as a status with an ErrorInfo detail per failed node
+func ingestionStatus(code codes.Code, profileId string, err error) error {
+	if errors.Is(err, data.ErrNotReady) {
+		code = codes.Unavailable
+	}
+	st := status.Newf(code, "profile %s failed ingestion: %v", profileId, err)
+	var nodeErrs data.NodeErrors
+	if !errors.As(err, &nodeErrs) {
+		return st.Err()
+	}
+	ids := make([]string, 0, len(nodeErrs))
+	for id := range nodeErrs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	details := make([]proto.Message, len(ids))
+	for i, id := range ids {
+		details[i] = &errdetails.ErrorInfo{
+			Reason: nodeFailureReason,
+			Domain: errorDomain,
+			Metadata: map[string]string{
+				"profile_id": profileId,
+				"node_id":    id,
+				"error":      nodeErrs[id].Error(),
+			},
 		}
-		return
-	}); err != nil {
-		return nil, nil, err
 	}
-	return nodes, edges, nil
+	if detailed, derr := st.WithDetails(details...); derr == nil {
+		st = detailed
+	} else {
+		log.Errorf("failed to detail ingestion status: %v", derr)
+	}
+	return st.Err()
 }
 
-func (graphService) CreateGraphProfile(profileId string,
-	model *onnx.ModelProto, opData map[string]*profile.FuncInfo) error {
-	pbGraph := model.GetGraph()
-	graph, annotations, err := transformGraph(pbGraph)
+// checkProfileId rejects ids the api couldn't have created, which are canonical uuids
+func checkProfileId(id string) error {
+	if parsed, err := uuid.Parse(id); err != nil || parsed.String() != id {
+		return status.Errorf(codes.InvalidArgument, "profile id %q is not a uuid", id)
+	}
+	return nil
+}
+
+func (svc *graphService) DeleteGraphProfile(id string) (*profile.DeleteProfileResponse, error) {
+	if err := checkProfileId(id); err != nil {
+		return nil, err
+	}
+	deleted, err := svc.store.DeleteProfile(id)
 	if err != nil {
+		return nil, err
+	}
+	return &profile.DeleteProfileResponse{
+		ProfileId:              id,
+		NodesDeleted:           uint64(deleted.Nodes),
+		AnnotationEdgesDeleted: uint64(deleted.AnnotationEdges),
+		BlobsDeleted:           uint64(deleted.Blobs),
+		BlobBytesDeleted:       uint64(deleted.BlobBytes),
+	}, nil
+}
+
+func (svc *graphService) PinGraphProfile(id string, pinned bool) error {
+	if err := checkProfileId(id); err != nil {
 		return err
 	}
-	annotationList := make([]interface{}, 0, len(annotations))
-	for _, annotation := range annotations {
-		annotationList = append(annotationList, annotation)
-	}
-	for id, node := range graph {
-		node.ProfileId = profileId
-		node.Args = make([]*data.TenncorNode, len(node.ArgIds))
-		for i, argId := range node.ArgIds {
-			node.Args[i] = graph[argId]
-		}
-		if op, ok := opData[id]; ok {
-			node.Runtime = op.GetRuntime()
-			if denseData := op.GetDenseData(); denseData != nil {
-				if variable, err := transformVariable(denseData); err != nil {
-					node.Shape = variable.Shape
-					node.Data = variable.Data
-				}
-			} else if sparseData := op.GetSparseData(); sparseData != nil {
-				if variable, err := transformSVariable(sparseData); err != nil {
-					node.Shape = variable.Shape
-					node.Data = variable.Data
-					node.Sinfo = variable.Sinfo
-				}
-			}
+	err := svc.store.SetProfilePinned(id, pinned)
+	if errors.Is(err, data.ErrNotFound) {
+		return status.Errorf(codes.NotFound, "profile %s not found", id)
+	}
+	return err
+}
+
+func (svc *graphService) GetTensorData(req *profile.GetTensorDataRequest) (*profile.GetTensorDataResponse, error) {
+	if err := checkProfileId(req.GetProfileId()); err != nil {
+		return nil, err
+	}
+	blob, err := svc.store.LoadBlob(req.GetProfileId(), req.GetNodeId())
+	if err == nil && !blobHasData(blob) {
+		// every node is saved with a blob, even those without a tensor
+		err = data.ErrNotFound
+	}
+	if errors.Is(err, data.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "no tensor data for node %s in profile %s",
+			req.GetNodeId(), req.GetProfileId())
+	}
+	if err != nil {
+		return nil, err
+	}
+	var (
+		shape       = blob.GetShape()
+		dtype       = onnx.TensorProto_DataType(blob.GetDtype())
+		width       = valueWidth(dtype)
+		strs        = blob.GetStrings()
+		dataShape   = shape
+		maxElements = req.GetMaxElements()
+		out         = &profile.GetTensorDataResponse{
+			Shape:        shape,
+			Dtype:        dtype.String(),
+			Indices:      blob.GetIndices(),
+			OuterIndices: blob.GetOuterIndices(),
 		}
+	)
+	values, err := blobValues(blob)
+	if err != nil {
+		return nil, status.Errorf(codes.DataLoss, "bad tensor data for node %s in profile %s: %v",
+			req.GetNodeId(), req.GetProfileId(), err)
 	}
-	outputs := pbGraph.GetOutput()
-	roots := make([]interface{}, len(outputs))
-	for i, output := range outputs {
-		roots[i] = graph[output.GetName()]
-	}
-	return data.WithTx(func(tx *data.Txn) (err error) {
-		var (
-			wg      sync.WaitGroup
-			blobWg  sync.WaitGroup
-			errChan = make(chan error, 0)
-		)
-		go func() {
-			for err = range errChan {
-				log.Error(err)
-			}
-		}()
-		// saving blob
-		log.Debug("saving node blob")
-		for id, node := range graph {
-			blob := &storage.BlobStorage{
-				Data: node.Data,
-			}
-			if node.Sinfo != nil {
-				blob.Indices = node.Sinfo.Indices
-				blob.OuterIndices = node.Sinfo.OuterIndices
-			}
-			data.AsyncSaveBlob(&blobWg, errChan, profileId, id, blob)
-		}
-		log.Debug("saving roots")
-		data.BatchCreateNodes(&wg, errChan, tx, roots, batchsize)
-		wg.Wait()
-		blobWg.Wait()
-		return
-	})
+	nelems := uint64(len(values)) / width
+	if dtype == onnx.TensorProto_STRING {
+		nelems = uint64(len(strs))
+	}
+	if len(out.Indices) > 0 {
+		if req.GetSlice() != "" {
+			return nil, status.Error(codes.InvalidArgument, "slicing sparse tensors is unsupported")
+		}
+		if maxElements > 0 && nelems > maxElements {
+			// keep indices aligned with the remaining values
+			perValue := uint64(len(out.Indices)) / nelems
+			out.Indices = out.Indices[:perValue*maxElements]
+			values, strs = truncateValues(values, strs, width, maxElements)
+			out.Truncated = true
+		}
+		out.Data = values
+		out.Strings = strs
+		return out, nil
+	}
+	if req.GetSlice() != "" {
+		// shapes are declared by the model, so only slice those matching the stored data
+		declared, err := shapeElements(shape)
+		if err != nil || declared != nelems {
+			return nil, status.Errorf(codes.FailedPrecondition,
+				"cannot slice node %s in profile %s: shape %v doesn't match its %d stored elements",
+				req.GetNodeId(), req.GetProfileId(), shape, nelems)
+		}
+		ranges, err := parseSlice(req.GetSlice(), shape)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		var offsets []uint64
+		offsets, dataShape = sliceOffsets(shape, ranges, nelems)
+		values = gatherValues(values, offsets, width)
+		strs = gatherStrings(strs, offsets)
+		nelems = uint64(len(offsets))
+	}
+	if maxElements > 0 && nelems > maxElements {
+		values, strs = truncateValues(values, strs, width, maxElements)
+		out.Truncated = true
+	}
+	out.Data = values
+	out.Strings = strs
+	out.DataShape = dataShape
+	return out, nil
 }
 
-func transformGraph(graph *onnx.GraphProto) (map[string]*data.TenncorNode, map[string]*data.Annotation, error) {
+// truncateValues keeps at most n elements of values and strs
+func truncateValues(values []float64, strs [][]byte, width, n uint64) ([]float64, [][]byte) {
+	if uint64(len(values)) > n*width {
+		values = values[:n*width]
+	}
+	if uint64(len(strs)) > n {
+		strs = strs[:n]
+	}
+	return values, strs
+}
+
+// transformGraph transforms every node in graph and its control-flow
+// subgraphs, nodes directly in graph are scoped under parent
+func transformGraph(graph *onnx.GraphProto, parent, scope string) (
+	map[string]*data.TenncorNode, map[string]*data.Annotation, error) {
 	var (
 		err  error
 		node *data.TenncorNode
@@ -223,6 +481,8 @@ func transformGraph(graph *onnx.GraphProto) (map[string]*data.TenncorNode, map[s
 		funcs  = graph.GetNode()
 
 		nodes                        = make(map[string]*data.TenncorNode)
+		nodeErrs                     = make(data.NodeErrors)
+		types                        = valueTypes(graph)
 		annotationEdges, annotations = getAnnotations(graph.GetQuantizationAnnotation())
 	)
 	for _, input := range inputs {
@@ -234,39 +494,66 @@ func transformGraph(graph *onnx.GraphProto) (map[string]*data.TenncorNode, map[s
 	for _, init := range inits {
 		id := init.GetName()
 		if node, err = transformVariable(init); err != nil {
-			return nil, nil, err
+			nodeErrs[id] = fmt.Errorf("bad initializer: %v", err)
+			continue
 		}
 		node.Annotations = annotationEdges[id]
+		if declared := outputTypes(node.Outputs, types); len(declared) > 0 {
+			node.OutputTypes = declared
+		}
 		nodes[id] = node
 	}
 	for _, sinit := range sinits {
 		id := sinit.GetValues().GetName()
 		if node, err = transformSVariable(sinit); err != nil {
-			return nil, nil, err
+			nodeErrs[id] = fmt.Errorf("bad sparse initializer: %v", err)
+			continue
 		}
 		node.Annotations = annotationEdges[id]
+		if declared := outputTypes(node.Outputs, types); len(declared) > 0 {
+			node.OutputTypes = declared
+		}
 		nodes[id] = node
 	}
 	for _, pbFnc := range funcs {
-		if subgraph, ok := getSubgraph(pbFnc); ok {
-			subNodes, subAnnotations, err := transformGraph(subgraph)
-			if err != nil {
+		id := funcId(pbFnc)
+		if node, err = transformFunc(pbFnc, nodes, annotations); err != nil {
+			return nil, nil, err
+		}
+		node.OutputTypes = outputTypes(node.Outputs, types)
+		nodes[id] = node
+	}
+	for _, node := range nodes {
+		node.Parent = parent
+		node.Scope = scope
+	}
+	// control-flow nodes stay as parents of their subgraphs
+	for _, pbFnc := range funcs {
+		for _, subgraph := range getSubgraphs(pbFnc) {
+			subNodes, subAnnotations, err := transformGraph(subgraph.graph, funcId(pbFnc), subgraph.scope)
+			var subErrs data.NodeErrors
+			if errors.As(err, &subErrs) {
+				for k, v := range subErrs {
+					nodeErrs[k] = v
+				}
+			} else if err != nil {
 				return nil, nil, err
 			}
 			for k, v := range subNodes {
+				if _, ok := nodes[k]; ok {
+					nodeErrs[k] = fmt.Errorf("duplicate node id in %s subgraph %s", funcId(pbFnc), subgraph.scope)
+					continue
+				}
 				nodes[k] = v
 			}
 			for k, v := range subAnnotations {
 				annotations[k] = v
 			}
-		} else {
-			id := pbFnc.GetName()
-			if node, err = transformFunc(pbFnc, nodes, annotations); err != nil {
-				return nil, nil, err
-			}
-			nodes[id] = node
 		}
 	}
+	if len(nodeErrs) > 0 {
+		return nil, nil, nodeErrs
+	}
 	return nodes, annotations, nil
 }
 
@@ -292,59 +579,55 @@ func getAnnotations(qAnnotations []*onnx.TensorAnnotation) (map[string][]*data.A
 
 func transformPlaceholder(input *onnx.ValueInfoProto) *data.TenncorNode {
 	id := input.GetName()
-	return &data.TenncorNode{
-		Uid: fmt.Sprintf("_:%s", id),
-		Id:  id,
+	node := &data.TenncorNode{
+		Uid:     fmt.Sprintf("_:%s", id),
+		Id:      id,
+		Kind:    data.PlaceholderKind,
+		Outputs: data.Values{id},
 	}
+	if input.GetType() != nil {
+		t := valueType(input)
+		node.Dtype = t.Dtype
+		node.OutputTypes = data.ValueTypes{t}
+		if shape, ok := staticShape(t); ok {
+			node.Shape = shape
+		}
+	}
+	return node
 }
 
 func transformVariable(init *onnx.TensorProto) (*data.TenncorNode, error) {
 	var (
-		tensordata []float64
-		dtype      = onnx.TensorProto_DataType(init.GetDataType())
+		raw   []byte
+		err   error
+		dtype = onnx.TensorProto_DataType(init.GetDataType())
 	)
-	switch dtype {
-	case onnx.TensorProto_DOUBLE:
-		tensordata = init.GetDoubleData()
-	case onnx.TensorProto_FLOAT:
-		fdata := init.GetFloatData()
-		tensordata = make([]float64, 0, len(fdata))
-		for _, f := range fdata {
-			tensordata = append(tensordata, float64(f))
-		}
-	case onnx.TensorProto_INT32, onnx.TensorProto_UINT8, onnx.TensorProto_UINT16, onnx.TensorProto_INT16:
-		idata := init.GetInt32Data()
-		tensordata = make([]float64, 0, len(idata))
-		for _, i := range idata {
-			tensordata = append(tensordata, float64(i))
-		}
-	case onnx.TensorProto_UINT32, onnx.TensorProto_UINT64:
-		udata := init.GetUint64Data()
-		tensordata = make([]float64, 0, len(udata))
-		for _, u := range udata {
-			tensordata = append(tensordata, float64(u))
-		}
-	case onnx.TensorProto_INT64:
-		idata := init.GetInt64Data()
-		tensordata = make([]float64, 0, len(idata))
-		for _, i := range idata {
-			tensordata = append(tensordata, float64(i))
-		}
-	default:
-		return nil, fmt.Errorf("bad variable type %s", dtype)
-	}
-
 	ds := init.GetDims()
 	dims := make([]uint64, len(ds))
 	for i, d := range ds {
 		dims[i] = uint64(d)
 	}
+	if raw = init.GetRawData(); len(raw) > 0 {
+		// exporters mostly serialize initializers as raw bytes
+		if err = checkRawData(dtype, raw, numElements(dims)); err != nil {
+			return nil, err
+		}
+	} else if raw, err = typedRawData(init); err != nil {
+		return nil, err
+	}
+
 	id := init.GetName()
 	return &data.TenncorNode{
-		Uid:   fmt.Sprintf("_:%s", id),
-		Id:    id,
-		Data:  tensordata,
-		Shape: dims,
+		Uid:     fmt.Sprintf("_:%s", id),
+		Id:      id,
+		Raw:     raw,
+		Strings: init.GetStringData(),
+		Outputs: data.Values{id},
+		// initializers are typed by their own data unless declared otherwise
+		OutputTypes: data.ValueTypes{{Name: id, Dtype: dtype.String(), Dims: shapeDims(dims)}},
+		Kind:        data.VariableKind,
+		Shape:       dims,
+		Dtype:       dtype.String(),
 	}, nil
 }
 
@@ -355,6 +638,7 @@ func transformSVariable(init *onnx.SparseTensorProto) (*data.TenncorNode, error)
 	}
 	inners := init.GetIndices().GetInt32Data()
 	outers := init.GetDims()
+	leaf.Kind = data.SparseVariableKind
 	leaf.Sinfo = &data.SparseInfo{
 		Indices:      inners,
 		OuterIndices: outers,
@@ -367,12 +651,15 @@ func transformFunc(fnc *onnx.NodeProto, nodes map[string]*data.TenncorNode, anno
 		val   interface{}
 		atype onnx.AttributeProto_AttributeType
 
-		id     = fnc.GetName()
+		id     = funcId(fnc)
 		attrs  = fnc.GetAttribute()
 		opname = fnc.GetOpType()
 
 		annotationEdges = make([]*data.Annotation, len(attrs))
 	)
+	if id == "" {
+		return nil, fmt.Errorf("%s function has no name or outputs", opname)
+	}
 	for i, attr := range attrs {
 		atype = attr.GetType()
 		switch atype {
@@ -381,31 +668,46 @@ func transformFunc(fnc *onnx.NodeProto, nodes map[string]*data.TenncorNode, anno
 		case onnx.AttributeProto_INT:
 			val = attr.GetI()
 		case onnx.AttributeProto_STRING:
-			val = attr.GetS()
+			val = string(attr.GetS())
 		case onnx.AttributeProto_FLOATS:
 			val = attr.GetFloats()
 		case onnx.AttributeProto_INTS:
 			val = attr.GetInts()
 		case onnx.AttributeProto_STRINGS:
-			val = attr.GetStrings()
+			pbStrs := attr.GetStrings()
+			strs := make([]string, len(pbStrs))
+			for j, str := range pbStrs {
+				strs[j] = string(str)
+			}
+			val = strs
 		case onnx.AttributeProto_TENSOR:
-			val = nodes[attr.GetT().GetName()]
+			val = tensorRef(nodes, attr.GetT())
 		case onnx.AttributeProto_SPARSE_TENSOR:
-			val = nodes[attr.GetSparseTensor().GetValues().GetName()]
+			val = tensorRef(nodes, attr.GetSparseTensor().GetValues())
 		case onnx.AttributeProto_TENSORS:
 			pbTens := attr.GetTensors()
-			tens := make([]interface{}, len(pbTens))
+			tens := make([]string, len(pbTens))
 			for j, tensor := range pbTens {
-				tens[j] = nodes[tensor.GetName()]
+				tens[j] = tensorRef(nodes, tensor)
 			}
 			val = tens
 		case onnx.AttributeProto_SPARSE_TENSORS:
 			pbTens := attr.GetSparseTensors()
-			tens := make([]interface{}, len(pbTens))
+			tens := make([]string, len(pbTens))
 			for j, tensor := range pbTens {
-				tens[j] = nodes[tensor.GetValues().GetName()]
+				tens[j] = tensorRef(nodes, tensor.GetValues())
 			}
 			val = tens
+		case onnx.AttributeProto_GRAPH:
+			// subgraphs are kept as scopes under the node
+			val = attr.GetG().GetName()
+		case onnx.AttributeProto_GRAPHS:
+			pbGraphs := attr.GetGraphs()
+			graphs := make([]string, len(pbGraphs))
+			for j, graph := range pbGraphs {
+				graphs[j] = graph.GetName()
+			}
+			val = graphs
 		default:
 			return nil, fmt.Errorf("unsupported attribute type %s", atype)
 		}
@@ -413,26 +715,57 @@ func transformFunc(fnc *onnx.NodeProto, nodes map[string]*data.TenncorNode, anno
 		annotations[annotation.Id] = annotation
 		annotationEdges[i] = annotation
 	}
-	inputs := fnc.GetInput()
-	argIds := make([]string, len(inputs))
-	for i, input := range inputs {
-		argIds[i] = input
-	}
 	return &data.TenncorNode{
 		Uid:         fmt.Sprintf("_:%s", id),
 		Id:          id,
 		Label:       opname,
+		Kind:        data.FunctionKind,
+		Domain:      fnc.GetDomain(),
 		Annotations: annotationEdges,
-		ArgIds:      argIds,
+		Inputs:      fnc.GetInput(),
+		Outputs:     fnc.GetOutput(),
 	}, nil
 }
 
-func getSubgraph(fnc *onnx.NodeProto) (*onnx.GraphProto, bool) {
-	attrs := fnc.GetAttribute()
-	for _, attr := range attrs {
-		if attr.GetType() == onnx.AttributeProto_GRAPH {
-			return attr.GetG(), true
+// funcId identifies fnc by name, or by its first output
+// since names are optional
+func funcId(fnc *onnx.NodeProto) string {
+	if name := fnc.GetName(); name != "" {
+		return name
+	}
+	for _, output := range fnc.GetOutput() {
+		if output != "" {
+			return output
+		}
+	}
+	return ""
+}
+
+// tensorRef describes an attribute tensor by its node id if it's
+// a known node, otherwise by its dtype and dims
+func tensorRef(nodes map[string]*data.TenncorNode, tensor *onnx.TensorProto) string {
+	if node, ok := nodes[tensor.GetName()]; ok {
+		return node.ToString()
+	}
+	return fmt.Sprintf("%s%v", onnx.TensorProto_DataType(tensor.GetDataType()), tensor.GetDims())
+}
+
+// getSubgraphs lists the subgraphs of a control-flow node
+// scoped by attribute name, indexed for GRAPHS attributes
+func getSubgraphs(fnc *onnx.NodeProto) []*subgraph {
+	var subgraphs []*subgraph
+	for _, attr := range fnc.GetAttribute() {
+		switch attr.GetType() {
+		case onnx.AttributeProto_GRAPH:
+			subgraphs = append(subgraphs, &subgraph{scope: attr.GetName(), graph: attr.GetG()})
+		case onnx.AttributeProto_GRAPHS:
+			for i, graph := range attr.GetGraphs() {
+				subgraphs = append(subgraphs, &subgraph{
+					scope: fmt.Sprintf("%s[%d]", attr.GetName(), i),
+					graph: graph,
+				})
+			}
 		}
 	}
-	return nil, false
+	return subgraphs
 }
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+type (
+	// dimRange is a half-open [start, stop) range over one dimension
+	dimRange struct {
+		start, stop uint64
+	}
+)
+
+// parseSlice parses numpy-style slices such as "0:2,:,1" against shape,
+// unspecified trailing dimensions are taken whole
+func parseSlice(spec string, shape []uint64) ([]dimRange, error) {
+	ranges := make([]dimRange, len(shape))
+	for i, d := range shape {
+		ranges[i] = dimRange{0, d}
+	}
+	if strings.TrimSpace(spec) == "" {
+		return ranges, nil
+	}
+	dims := strings.Split(spec, ",")
+	if len(dims) > len(shape) {
+		return nil, fmt.Errorf("slice %s has more dimensions than shape %v", spec, shape)
+	}
+	for i, dim := range dims {
+		var (
+			err   error
+			bound = shape[i]
+			r     = dimRange{0, bound}
+			parts = strings.Split(strings.TrimSpace(dim), ":")
+		)
+		switch len(parts) {
+		case 1:
+			if r.start, err = parseIndex(parts[0], 0); err != nil {
+				return nil, err
+			}
+			r.stop = r.start + 1
+		case 2:
+			if r.start, err = parseIndex(parts[0], 0); err != nil {
+				return nil, err
+			}
+			if r.stop, err = parseIndex(parts[1], bound); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("bad slice dimension %s", dim)
+		}
+		if r.stop > bound {
+			r.stop = bound
+		}
+		if r.start >= r.stop {
+			return nil, fmt.Errorf("empty slice %s on dimension %d of size %d", dim, i, bound)
+		}
+		ranges[i] = r
+	}
+	return ranges, nil
+}
+
+func parseIndex(s string, def uint64) (uint64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return def, nil
+	}
+	return strconv.ParseUint(s, 10, 64)
+}
+
+// shapeElements is the number of elements in shape, failing when it overflows
+func shapeElements(shape []uint64) (uint64, error) {
+	n := uint64(1)
+	for _, d := range shape {
+		hi, lo := bits.Mul64(n, d)
+		if hi != 0 {
+			return 0, fmt.Errorf("shape %v has too many elements", shape)
+		}
+		n = lo
+	}
+	return n, nil
+}
+
+// sliceOffsets lists the row-major offsets of elements within ranges of shape,
+// shape must have nelems elements which bounds the offsets listed
+func sliceOffsets(shape []uint64, ranges []dimRange, nelems uint64) ([]uint64, []uint64) {
+	outShape := make([]uint64, len(ranges))
+	nout := uint64(1)
+	for i, r := range ranges {
+		outShape[i] = r.stop - r.start
+		nout *= outShape[i]
+	}
+	if len(shape) == 0 {
+		return []uint64{0}, outShape
+	}
+	strides := make([]uint64, len(shape))
+	stride := uint64(1)
+	for i := len(shape) - 1; i >= 0; i-- {
+		strides[i] = stride
+		stride *= shape[i]
+	}
+	if nout > nelems {
+		nout = nelems
+	}
+	out := make([]uint64, 0, nout)
+	index := make([]uint64, len(ranges))
+	for i, r := range ranges {
+		index[i] = r.start
+	}
+	for {
+		offset := uint64(0)
+		for i, idx := range index {
+			offset += idx * strides[i]
+		}
+		out = append(out, offset)
+		// increment the multi-dimensional index starting from the last dimension
+		i := len(index) - 1
+		for ; i >= 0; i-- {
+			index[i]++
+			if index[i] < ranges[i].stop {
+				break
+			}
+			index[i] = ranges[i].start
+		}
+		if i < 0 {
+			break
+		}
+	}
+	return out, outShape
+}
+
+// gatherValues picks elements of width values at offsets,
+// skipping offsets past the end of data
+func gatherValues(data []float64, offsets []uint64, width uint64) []float64 {
+	out := make([]float64, 0, uint64(len(offsets))*width)
+	for _, offset := range offsets {
+		if (offset+1)*width <= uint64(len(data)) {
+			out = append(out, data[offset*width:(offset+1)*width]...)
+		}
+	}
+	return out
+}
+
+func gatherStrings(data [][]byte, offsets []uint64) [][]byte {
+	out := make([][]byte, 0, len(offsets))
+	for _, offset := range offsets {
+		if offset < uint64(len(data)) {
+			out = append(out, data[offset])
+		}
+	}
+	return out
+}
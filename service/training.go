@@ -0,0 +1,164 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/mingkaic/onnx_go/onnx"
+
+	"github.com/mingkaic/accretion/data"
+	"github.com/mingkaic/accretion/proto/profile"
+)
+
+const (
+	initializationSection = "initialization"
+	algorithmSection      = "algorithm"
+)
+
+// transformTraining adds the initialization and algorithm graphs of infos
+// to graph as sections and binds initializers to the values initializing
+// and updating them, the outputs of every added graph are returned
+func transformTraining(infos []*onnx.TrainingInfoProto, graph map[string]*data.TenncorNode) ([]string, error) {
+	var (
+		outputs  []string
+		nodeErrs = make(data.NodeErrors)
+	)
+	for i, info := range infos {
+		for _, training := range []struct {
+			name  string
+			graph *onnx.GraphProto
+		}{
+			{initializationSection, info.GetInitialization()},
+			{algorithmSection, info.GetAlgorithm()},
+		} {
+			sectionGraph := training.graph
+			if sectionGraph == nil {
+				continue
+			}
+			section := fmt.Sprintf("%s[%d]", training.name, i)
+			nodes, _, err := transformGraph(sectionGraph, "", "")
+			var subErrs data.NodeErrors
+			if errors.As(err, &subErrs) {
+				for k, v := range subErrs {
+					nodeErrs[k] = fmt.Errorf("%s: %v", section, v)
+				}
+				continue
+			} else if err != nil {
+				return nil, fmt.Errorf("%s: %v", section, err)
+			}
+			for id, node := range nodes {
+				if _, ok := graph[id]; ok {
+					// algorithm inputs may name initializers of the main graph
+					if node.Kind == data.PlaceholderKind {
+						continue
+					}
+					nodeErrs[id] = fmt.Errorf("duplicate node id in %s", section)
+					continue
+				}
+				node.Section = section
+				graph[id] = node
+			}
+			for _, output := range sectionGraph.GetOutput() {
+				outputs = append(outputs, output.GetName())
+			}
+		}
+		for _, binding := range info.GetInitializationBinding() {
+			if initializer := boundInitializer(graph, binding.GetKey()); initializer != nil {
+				initializer.InitializedBy = append(initializer.InitializedBy, binding.GetValue())
+			} else {
+				nodeErrs[binding.GetKey()] = errors.New("initialization binding of unknown initializer")
+			}
+		}
+		for _, binding := range info.GetUpdateBinding() {
+			if initializer := boundInitializer(graph, binding.GetKey()); initializer != nil {
+				initializer.UpdatedBy = append(initializer.UpdatedBy, binding.GetValue())
+			} else {
+				nodeErrs[binding.GetKey()] = errors.New("update binding of unknown initializer")
+			}
+		}
+	}
+	if len(nodeErrs) > 0 {
+		return nil, nodeErrs
+	}
+	return outputs, nil
+}
+
+// boundInitializer is the initializer of the main graph named id, or nil
+func boundInitializer(graph map[string]*data.TenncorNode, id string) *data.TenncorNode {
+	node, ok := graph[id]
+	if !ok || node.Section != "" || node.Parent != "" ||
+		(node.Kind != data.VariableKind && node.Kind != data.SparseVariableKind) {
+		return nil
+	}
+	return node
+}
+
+// nodeSection is the section of node, nodes of the main graph are inference
+func nodeSection(node *data.TenncorNode) string {
+	if node.Section == "" {
+		return data.InferenceSection
+	}
+	return node.Section
+}
+
+// profileSections totals runtime per section, inference first
+// then training sections by name
+func profileSections(nodes []*data.TenncorNode) []*profile.ProfileSection {
+	bySection := map[string]*profile.ProfileSection{
+		data.InferenceSection: {Name: data.InferenceSection},
+	}
+	for _, node := range nodes {
+		name := nodeSection(node)
+		section, ok := bySection[name]
+		if !ok {
+			section = &profile.ProfileSection{Name: name}
+			bySection[name] = section
+		}
+		section.Runtime += node.Runtime
+		section.NodeCount++
+	}
+	sections := make([]*profile.ProfileSection, 0, len(bySection))
+	for _, section := range bySection {
+		sections = append(sections, section)
+	}
+	sort.Slice(sections, func(i, j int) bool {
+		if sections[i].Name == data.InferenceSection {
+			return true
+		}
+		if sections[j].Name == data.InferenceSection {
+			return false
+		}
+		return sections[i].Name < sections[j].Name
+	})
+	return sections
+}
+
+// bindingEdges points initializer node to the producers of values
+// initializing and updating it
+func bindingEdges(node *data.TenncorNode, producers map[string]string) []*profile.SigmaEdge {
+	var edges []*profile.SigmaEdge
+	for _, binding := range []struct {
+		kind   profile.EdgeKind
+		values data.Values
+	}{
+		{profile.EdgeKind_INITIALIZATION, node.InitializedBy},
+		{profile.EdgeKind_UPDATE, node.UpdatedBy},
+	} {
+		for _, value := range binding.values {
+			producer, ok := producers[value]
+			if !ok {
+				continue
+			}
+			edges = append(edges, &profile.SigmaEdge{
+				Id:     uuid.NewString(),
+				Source: node.Id,
+				Target: producer,
+				Label:  value,
+				Kind:   binding.kind,
+			})
+		}
+	}
+	return edges
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/mingkaic/onnx_go/onnx"
+
+	"github.com/mingkaic/accretion/data"
+	"github.com/mingkaic/accretion/proto/profile"
+)
+
+const unknownDim = "?"
+
+// valueTypes indexes the declared types of graph inputs,
+// outputs and intermediate values by value name
+func valueTypes(graph *onnx.GraphProto) map[string]*data.ValueType {
+	types := make(map[string]*data.ValueType)
+	for _, infos := range [][]*onnx.ValueInfoProto{
+		graph.GetInput(), graph.GetValueInfo(), graph.GetOutput()} {
+		for _, info := range infos {
+			if info.GetType() != nil {
+				types[info.GetName()] = valueType(info)
+			}
+		}
+	}
+	return types
+}
+
+func valueType(info *onnx.ValueInfoProto) *data.ValueType {
+	out := &data.ValueType{
+		Name:  info.GetName(),
+		Dtype: typeString(info.GetType()),
+	}
+	if tensor := info.GetType().GetTensorType(); tensor != nil {
+		dims := tensor.GetShape().GetDim()
+		out.Dims = make([]string, len(dims))
+		for i, dim := range dims {
+			switch {
+			case dim.GetDimParam() != "":
+				out.Dims[i] = dim.GetDimParam()
+			case dim.GetValue() != nil:
+				out.Dims[i] = strconv.FormatInt(dim.GetDimValue(), 10)
+			default:
+				out.Dims[i] = unknownDim
+			}
+		}
+	}
+	return out
+}
+
+func typeString(t *onnx.TypeProto) string {
+	switch {
+	case t.GetTensorType() != nil:
+		return onnx.TensorProto_DataType(t.GetTensorType().GetElemType()).String()
+	case t.GetSequenceType() != nil:
+		return fmt.Sprintf("seq(%s)", typeString(t.GetSequenceType().GetElemType()))
+	case t.GetMapType() != nil:
+		return fmt.Sprintf("map(%s,%s)",
+			onnx.TensorProto_DataType(t.GetMapType().GetKeyType()),
+			typeString(t.GetMapType().GetValueType()))
+	}
+	return ""
+}
+
+// staticShape is the shape of t if every dim is known
+func staticShape(t *data.ValueType) (data.Shape, bool) {
+	shape := make(data.Shape, len(t.Dims))
+	for i, dim := range t.Dims {
+		d, err := strconv.ParseUint(dim, 10, 64)
+		if err != nil {
+			return nil, false
+		}
+		shape[i] = d
+	}
+	return shape, true
+}
+
+func shapeDims(shape []uint64) []string {
+	dims := make([]string, len(shape))
+	for i, d := range shape {
+		dims[i] = strconv.FormatUint(d, 10)
+	}
+	return dims
+}
+
+// outputTypes lists the declared types of outputs in order
+func outputTypes(outputs data.Values, types map[string]*data.ValueType) data.ValueTypes {
+	var out data.ValueTypes
+	for _, output := range outputs {
+		if t, ok := types[output]; ok {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+func sigmaValueTypes(types data.ValueTypes) []*profile.ValueType {
+	if len(types) == 0 {
+		return nil
+	}
+	out := make([]*profile.ValueType, len(types))
+	for i, t := range types {
+		out[i] = &profile.ValueType{
+			Name:  t.Name,
+			Dtype: t.Dtype,
+			Dims:  t.Dims,
+		}
+	}
+	return out
+}
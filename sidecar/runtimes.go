@@ -0,0 +1,51 @@
+// Package sidecar reads operator data sent alongside onnx models,
+// shared by the http upload and accretionctl
+package sidecar
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// ReadRuntimes reads runtimes by node id from a CSV file if filename
+// ends in .csv, otherwise from a JSON object
+func ReadRuntimes(filename string, in io.Reader) (map[string]uint64, error) {
+	if strings.EqualFold(path.Ext(filename), ".csv") {
+		return csvRuntimes(in)
+	}
+	var runtimes map[string]uint64
+	if err := json.NewDecoder(in).Decode(&runtimes); err != nil {
+		return nil, err
+	}
+	return runtimes, nil
+}
+
+// csvRuntimes reads rows of node id then runtime, skipping a header row
+func csvRuntimes(in io.Reader) (map[string]uint64, error) {
+	reader := csv.NewReader(in)
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+	runtimes := make(map[string]uint64)
+	for row := 0; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return runtimes, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		nodeRuntime, err := strconv.ParseUint(record[1], 10, 64)
+		if err != nil {
+			if row == 0 {
+				continue
+			}
+			return nil, fmt.Errorf("row %d: bad runtime %q", row+1, record[1])
+		}
+		runtimes[record[0]] = nodeRuntime
+	}
+}